@@ -0,0 +1,268 @@
+// Package cwe is an offline catalog of CWE weaknesses used to enrich the weaknesses returned by the HackerOne API with
+// their place in the CWE hierarchy, OWASP Top 10 categories and related CAPEC attack patterns.
+//
+// The embedded dataset covers the pillars, classes and common bases and variants of the CWE research view (CWE-1000)
+// that HackerOne programs use. Weaknesses outside of it are left unenriched, a complete catalog can be loaded with
+// Load.
+package cwe
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ryanjarv/h1/pkg/types"
+)
+
+//go:embed cwe.json
+var dataset []byte
+
+// OWASPTop10 names the OWASP Top 10 2021 categories used in Entry.OWASP.
+var OWASPTop10 = map[string]string{
+	"A01:2021": "Broken Access Control",
+	"A02:2021": "Cryptographic Failures",
+	"A03:2021": "Injection",
+	"A04:2021": "Insecure Design",
+	"A05:2021": "Security Misconfiguration",
+	"A06:2021": "Vulnerable and Outdated Components",
+	"A07:2021": "Identification and Authentication Failures",
+	"A08:2021": "Software and Data Integrity Failures",
+	"A09:2021": "Security Logging and Monitoring Failures",
+	"A10:2021": "Server-Side Request Forgery (SSRF)",
+}
+
+// Entry is a single CWE weakness.
+//
+// Abstraction is one of Pillar, Class, Base, Variant or Compound. Parents are the ChildOf relationships in the
+// research view, Children is filled in from them when the catalog is loaded.
+type Entry struct {
+	Id          int      `json:"id"`
+	Name        string   `json:"name"`
+	Abstraction string   `json:"abstraction"`
+	Parents     []int    `json:"parents,omitempty"`
+	Children    []int    `json:"-"`
+	OWASP       []string `json:"owasp,omitempty"`
+	CAPEC       []int    `json:"capec,omitempty"`
+}
+
+// ExternalId returns the id in the form used by HackerOne weaknesses, e.g. cwe-79.
+func (e *Entry) ExternalId() string {
+	return "cwe-" + strconv.Itoa(e.Id)
+}
+
+// Catalog is a set of CWE entries indexed by id.
+type Catalog struct {
+	entries map[int]*Entry
+}
+
+var defaultCatalog = sync.OnceValues(func() (*Catalog, error) {
+	return Load(bytes.NewReader(dataset))
+})
+
+// Default returns the catalog of the embedded dataset.
+func Default() *Catalog {
+	catalog, err := defaultCatalog()
+	if err != nil {
+		panic(fmt.Sprintf("cwe: embedded dataset: %s", err))
+	}
+	return catalog
+}
+
+// Load reads a catalog from a JSON array of entries in the format of the embedded dataset. Parents that aren't part
+// of the catalog are dropped.
+func Load(r io.Reader) (*Catalog, error) {
+	var entries []*Entry
+	if err := json.NewDecoder(r).Decode(&entries); err != nil {
+		return nil, fmt.Errorf("Load: failed to unmarshal catalog: %w", err)
+	}
+
+	c := &Catalog{entries: make(map[int]*Entry, len(entries))}
+	for i, entry := range entries {
+		if entry == nil {
+			return nil, fmt.Errorf("Load: entry %d is null", i)
+		} else if _, ok := c.entries[entry.Id]; ok {
+			return nil, fmt.Errorf("Load: duplicate entry CWE-%d", entry.Id)
+		}
+		c.entries[entry.Id] = entry
+	}
+
+	for _, entry := range c.entries {
+		entry.Parents = slices.DeleteFunc(entry.Parents, func(id int) bool { return c.entries[id] == nil })
+		for _, parent := range entry.Parents {
+			c.entries[parent].Children = append(c.entries[parent].Children, entry.Id)
+		}
+	}
+	for _, entry := range c.entries {
+		slices.Sort(entry.Children)
+	}
+
+	return c, nil
+}
+
+// ParseExternalId returns the CWE number of ids like cwe-79 or CWE-79.
+func ParseExternalId(externalId string) (int, error) {
+	prefix, number, ok := strings.Cut(externalId, "-")
+	if !ok || !strings.EqualFold(prefix, "cwe") {
+		return 0, fmt.Errorf("ParseExternalId: %q is not a CWE id", externalId)
+	}
+
+	id, err := strconv.Atoi(number)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("ParseExternalId: %q is not a CWE id", externalId)
+	}
+
+	return id, nil
+}
+
+// Lookup returns the entry with the given CWE number.
+func (c *Catalog) Lookup(id int) (*Entry, bool) {
+	entry, ok := c.entries[id]
+	return entry, ok
+}
+
+// LookupExternalId returns the entry of a HackerOne weakness external id such as cwe-79.
+func (c *Catalog) LookupExternalId(externalId string) (*Entry, bool) {
+	id, err := ParseExternalId(externalId)
+	if err != nil {
+		return nil, false
+	}
+	return c.Lookup(id)
+}
+
+// Entries returns every entry ordered by id.
+func (c *Catalog) Entries() []*Entry {
+	entries := make([]*Entry, 0, len(c.entries))
+	for _, entry := range c.entries {
+		entries = append(entries, entry)
+	}
+	slices.SortFunc(entries, func(a, b *Entry) int { return a.Id - b.Id })
+	return entries
+}
+
+// Ancestors returns every entry id descends from, nearest first. Entries reachable through several parents are only
+// returned once.
+func (c *Catalog) Ancestors(id int) []*Entry {
+	return c.walk(id, func(e *Entry) []int { return e.Parents })
+}
+
+// Descendants returns every entry descending from id, nearest first.
+func (c *Catalog) Descendants(id int) []*Entry {
+	return c.walk(id, func(e *Entry) []int { return e.Children })
+}
+
+// IsA reports whether id is ancestor or one of its descendants.
+func (c *Catalog) IsA(id, ancestor int) bool {
+	if id == ancestor {
+		return true
+	}
+	return slices.ContainsFunc(c.Ancestors(id), func(e *Entry) bool { return e.Id == ancestor })
+}
+
+// Search returns the entries whose id, name, OWASP category or CAPEC id matches query, ordered by id. Matching is
+// case insensitive, e.g. "injection", "cwe-79", "A03" or "capec-66".
+func (c *Catalog) Search(query string) []*Entry {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return nil
+	}
+
+	var matches []*Entry
+	for _, entry := range c.Entries() {
+		if entry.matches(query) {
+			matches = append(matches, entry)
+		}
+	}
+	return matches
+}
+
+func (e *Entry) matches(query string) bool {
+	if query == strconv.Itoa(e.Id) || query == e.ExternalId() || strings.Contains(strings.ToLower(e.Name), query) {
+		return true
+	}
+	for _, category := range e.OWASP {
+		if strings.HasPrefix(strings.ToLower(category), query) || strings.Contains(strings.ToLower(OWASPTop10[category]), query) {
+			return true
+		}
+	}
+	if number, ok := strings.CutPrefix(query, "capec-"); ok {
+		id, err := strconv.Atoi(number)
+		return err == nil && slices.Contains(e.CAPEC, id)
+	}
+	return false
+}
+
+// walk does a breadth first walk from id following next.
+func (c *Catalog) walk(id int, next func(*Entry) []int) []*Entry {
+	start, ok := c.entries[id]
+	if !ok {
+		return nil
+	}
+
+	seen := map[int]bool{id: true}
+	var found []*Entry
+	queue := next(start)
+	for len(queue) != 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if seen[current] {
+			continue
+		}
+		seen[current] = true
+
+		entry := c.entries[current]
+		found = append(found, entry)
+		queue = append(queue, next(entry)...)
+	}
+
+	return found
+}
+
+// Weakness is a HackerOne weakness with its CWE entry, CWE is nil when the weakness has no CWE id or it isn't in
+// the catalog.
+type Weakness struct {
+	types.Weakness
+	CWE *Entry `json:"cwe,omitempty"`
+}
+
+// Enrich attaches the CWE entry of every weakness by its external id.
+func (c *Catalog) Enrich(weaknesses []types.Weakness) []Weakness {
+	enriched := make([]Weakness, 0, len(weaknesses))
+	for _, weakness := range weaknesses {
+		entry, _ := c.LookupExternalId(weakness.Attributes.ExternalId)
+		enriched = append(enriched, Weakness{Weakness: weakness, CWE: entry})
+	}
+	return enriched
+}
+
+// GroupByOWASP groups weaknesses by their OWASP Top 10 categories, a weakness in several categories is in each of
+// their groups. Weaknesses without a category are not grouped.
+func GroupByOWASP(weaknesses []Weakness) map[string][]Weakness {
+	groups := map[string][]Weakness{}
+	for _, weakness := range weaknesses {
+		if weakness.CWE == nil {
+			continue
+		}
+		for _, category := range weakness.CWE.OWASP {
+			groups[category] = append(groups[category], weakness)
+		}
+	}
+	return groups
+}
+
+// Under returns the weaknesses that are the CWE ancestor or descend from it, e.g. Under(weaknesses, 74) for every
+// kind of injection.
+func (c *Catalog) Under(weaknesses []Weakness, ancestor int) []Weakness {
+	var under []Weakness
+	for _, weakness := range weaknesses {
+		if weakness.CWE != nil && c.IsA(weakness.CWE.Id, ancestor) {
+			under = append(under, weakness)
+		}
+	}
+	return under
+}
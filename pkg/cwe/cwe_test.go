@@ -0,0 +1,128 @@
+package cwe
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/ryanjarv/h1/pkg/types"
+)
+
+func ids(entries []*Entry) []int {
+	var ids []int
+	for _, entry := range entries {
+		ids = append(ids, entry.Id)
+	}
+	return ids
+}
+
+func TestDefault(t *testing.T) {
+	c := Default()
+
+	xss, ok := c.LookupExternalId("CWE-79")
+	if !ok {
+		t.Fatal("LookupExternalId(CWE-79) not found")
+	}
+	if xss.Abstraction != "Base" || !slices.Contains(xss.OWASP, "A03:2021") || !slices.Contains(xss.CAPEC, 63) {
+		t.Errorf("LookupExternalId(CWE-79) = %+v", xss)
+	}
+	if diff := cmp.Diff([]int{80, 83}, xss.Children); diff != "" {
+		t.Errorf("CWE-79 children mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]int{74, 707}, ids(c.Ancestors(79))); diff != "" {
+		t.Errorf("Ancestors(79) mismatch (-want +got):\n%s", diff)
+	}
+	if !c.IsA(89, 74) || c.IsA(79, 943) {
+		t.Error("IsA() doesn't follow the hierarchy")
+	}
+
+	// Every parent must be in the dataset and every entry must reach a pillar.
+	for _, entry := range c.Entries() {
+		if len(entry.Parents) == 0 && entry.Abstraction != "Pillar" {
+			t.Errorf("CWE-%d has no parents in the dataset", entry.Id)
+		}
+		for _, category := range entry.OWASP {
+			if _, ok := OWASPTop10[category]; !ok {
+				t.Errorf("CWE-%d has unknown OWASP category %s", entry.Id, category)
+			}
+		}
+	}
+
+	if _, ok := c.LookupExternalId("capec-66"); ok {
+		t.Error("LookupExternalId(capec-66) found an entry")
+	}
+}
+
+func TestCatalog_Search(t *testing.T) {
+	c := Default()
+
+	for query, want := range map[string]int{
+		"cross-site scripting": 79,
+		"cwe-918":              918,
+		"a10":                  918,
+		"capec-66":             89,
+	} {
+		if got := ids(c.Search(query)); !slices.Contains(got, want) {
+			t.Errorf("Search(%q) = %v, want CWE-%d", query, got, want)
+		}
+	}
+	if got := c.Search(""); got != nil {
+		t.Errorf("Search(\"\") = %v, want nil", got)
+	}
+}
+
+func TestCatalog_Enrich(t *testing.T) {
+	c := Default()
+
+	weakness := func(id, externalId string) types.Weakness {
+		return types.Weakness{Id: id, Attributes: types.WeaknessAttributes{ExternalId: externalId}}
+	}
+	enriched := c.Enrich([]types.Weakness{
+		weakness("60", "cwe-79"),
+		weakness("61", "cwe-89"),
+		weakness("62", "cwe-639"),
+		weakness("63", "capec-1"),
+	})
+
+	if enriched[0].CWE == nil || enriched[0].CWE.Id != 79 || enriched[3].CWE != nil {
+		t.Errorf("Enrich() = %+v", enriched)
+	}
+
+	groups := GroupByOWASP(enriched)
+	if len(groups["A03:2021"]) != 2 || len(groups["A01:2021"]) != 1 {
+		t.Errorf("GroupByOWASP() = %v", groups)
+	}
+
+	var injections []string
+	for _, weakness := range c.Under(enriched, 74) {
+		injections = append(injections, weakness.Id)
+	}
+	if got := strings.Join(injections, ","); got != "60,61" {
+		t.Errorf("Under(74) = %s, want 60,61", got)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{name: "parents outside the catalog", input: `[{"id": 79, "parents": [74]}]`},
+		{name: "duplicate entry", input: `[{"id": 79}, {"id": 79}]`, wantErr: true},
+		{name: "null entry", input: `[{"id": 79}, null]`, wantErr: true},
+		{name: "not an array", input: `{"id": 79}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := Load(strings.NewReader(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			} else if err == nil && len(c.Entries()) != 1 {
+				t.Errorf("Load() = %+v, want one entry", c.Entries())
+			}
+		})
+	}
+}
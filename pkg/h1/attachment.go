@@ -0,0 +1,153 @@
+package h1
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// MaxAttachmentSize is the largest file accepted as an attachment, larger files are rejected before uploading.
+var MaxAttachmentSize int64 = 100 << 20
+
+// Attachment is a file on disk to be uploaded with a report or comment.
+//
+// Path must be provided.
+// ContentType is optional, when empty it is detected from the file's contents and extension.
+type Attachment struct {
+	Path string `json:"path"`
+
+	ContentType string `json:"content_type,omitempty"`
+}
+
+// NewAttachment checks that the file at path can be uploaded and detects its content type.
+func NewAttachment(path string) (*Attachment, error) {
+	a := &Attachment{Path: path}
+	if err := a.check(); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// check stats the file, enforces MaxAttachmentSize and fills in ContentType if it isn't set.
+func (a *Attachment) check() error {
+	info, err := os.Stat(a.Path)
+	if err != nil {
+		return fmt.Errorf("attachment %s: %w", a.Path, err)
+	} else if !info.Mode().IsRegular() {
+		return fmt.Errorf("attachment %s: not a regular file", a.Path)
+	} else if info.Size() == 0 {
+		return fmt.Errorf("attachment %s: file is empty", a.Path)
+	} else if info.Size() > MaxAttachmentSize {
+		return fmt.Errorf("attachment %s: %d bytes exceeds the %d byte limit", a.Path, info.Size(), MaxAttachmentSize)
+	}
+
+	if a.ContentType == "" {
+		if a.ContentType, err = detectContentType(a.Path); err != nil {
+			return fmt.Errorf("attachment %s: %w", a.Path, err)
+		}
+	}
+
+	return nil
+}
+
+// detectContentType sniffs the start of the file, falling back to the extension when sniffing is inconclusive.
+func detectContentType(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(f, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return "", fmt.Errorf("reading: %w", err)
+	}
+
+	detected := http.DetectContentType(head[:n])
+	if detected != "application/octet-stream" && !strings.HasPrefix(detected, "text/plain") {
+		return detected, nil
+	}
+
+	if byExt := mime.TypeByExtension(filepath.Ext(path)); byExt != "" {
+		return byExt, nil
+	}
+	return detected, nil
+}
+
+type formField struct {
+	name  string
+	value string
+}
+
+type formFile struct {
+	name       string
+	attachment *Attachment
+}
+
+// multipartBody streams a multipart/form-data body, files are copied from disk as the request is sent rather than
+// buffered in memory. Every call starts a new stream so the body can be recreated when a request is retried.
+func multipartBody(fields []formField, files []formFile) bodyFunc {
+	return func() (io.Reader, string, error) {
+		pr, pw := io.Pipe()
+		writer := multipart.NewWriter(pw)
+
+		go func() {
+			pw.CloseWithError(writeMultipart(writer, fields, files))
+		}()
+
+		return pr, writer.FormDataContentType(), nil
+	}
+}
+
+func writeMultipart(writer *multipart.Writer, fields []formField, files []formFile) error {
+	for _, field := range fields {
+		if err := writer.WriteField(field.name, field.value); err != nil {
+			return fmt.Errorf("writing field %s: %w", field.name, err)
+		}
+	}
+
+	for _, file := range files {
+		if err := writeFile(writer, file); err != nil {
+			return err
+		}
+	}
+
+	return writer.Close()
+}
+
+func writeFile(writer *multipart.Writer, file formFile) error {
+	f, err := os.Open(file.attachment.Path)
+	if err != nil {
+		return fmt.Errorf("opening attachment: %w", err)
+	}
+	defer f.Close()
+
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Disposition", mime.FormatMediaType("form-data", map[string]string{
+		"name":     file.name,
+		"filename": filepath.Base(file.attachment.Path),
+	}))
+	header.Set("Content-Type", file.attachment.ContentType)
+
+	part, err := writer.CreatePart(header)
+	if err != nil {
+		return fmt.Errorf("creating part for %s: %w", file.attachment.Path, err)
+	}
+
+	// The size was checked up front, the limit only guards against the file growing while it is uploaded.
+	if n, err := io.Copy(part, io.LimitReader(f, MaxAttachmentSize+1)); err != nil {
+		return fmt.Errorf("copying %s: %w", file.attachment.Path, err)
+	} else if n > MaxAttachmentSize {
+		return fmt.Errorf("attachment %s: grew past the %d byte limit while uploading", file.attachment.Path, MaxAttachmentSize)
+	}
+
+	return nil
+}
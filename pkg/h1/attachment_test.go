@@ -0,0 +1,151 @@
+package h1
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// recordingClient reads each request body while the request is in flight, streamed bodies are closed once Do returns.
+type recordingClient struct {
+	*MockClient
+	Bodies [][]byte
+}
+
+func (c *recordingClient) Do(req *http.Request) (*http.Response, error) {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	c.Bodies = append(c.Bodies, body)
+	return c.MockClient.Do(req)
+}
+
+func TestNewAttachment(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name string, data []byte) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, data, 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	tests := []struct {
+		name            string
+		path            string
+		maxSize         int64
+		wantContentType string
+		wantErr         bool
+	}{
+		{name: "sniffs content", path: write("screenshot", []byte("\x89PNG\r\n\x1a\n0000")), wantContentType: "image/png"},
+		{name: "falls back to the extension", path: write("poc.json", []byte(`{"a": 1}`)), wantContentType: "application/json"},
+		{name: "plain text", path: write("notes", []byte("hello")), wantContentType: "text/plain; charset=utf-8"},
+		{name: "empty file", path: write("empty.txt", nil), wantErr: true},
+		{name: "missing file", path: filepath.Join(dir, "missing"), wantErr: true},
+		{name: "too large", path: write("large.bin", bytes.Repeat([]byte{0}, 32)), maxSize: 16, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.maxSize != 0 {
+				defer func(size int64) { MaxAttachmentSize = size }(MaxAttachmentSize)
+				MaxAttachmentSize = tt.maxSize
+			}
+
+			got, err := NewAttachment(tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewAttachment() error = %v, wantErr %v", err, tt.wantErr)
+			} else if err != nil {
+				return
+			}
+			if got.ContentType != tt.wantContentType {
+				t.Errorf("NewAttachment() content type = %q, want %q", got.ContentType, tt.wantContentType)
+			}
+		})
+	}
+}
+
+func TestReport_Comment_Attachments(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "poc.html")
+	if err := os.WriteFile(path, []byte("<html><script>alert(1)</script></html>"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	client := &recordingClient{MockClient: &MockClient{
+		DoResponse: []*http.Response{{
+			StatusCode: 201,
+			Body:       io.NopCloser(bytes.NewReader([]byte(`{"id": "5", "type": "activity-comment", "attributes": {"message": "PoC attached"}}`))),
+		}},
+	}}
+	h1 := &Hackerone{token: "token", username: "username", client: client}
+
+	got, err := h1.Report("1337").Comment("PoC attached", path)
+	if err != nil {
+		t.Fatalf("Comment() error = %v", err)
+	}
+	if got.Id != "5" {
+		t.Errorf("Comment() id = %s, want 5", got.Id)
+	}
+
+	mediaType, params, err := mime.ParseMediaType(client.Calls[0].Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/form-data" {
+		t.Fatalf("Comment() content type = %q, want multipart/form-data", client.Calls[0].Header.Get("Content-Type"))
+	}
+
+	parts := map[string]string{}
+	var fileType string
+	reader := multipart.NewReader(bytes.NewReader(client.Bodies[0]), params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatalf("reading multipart body: %s", err)
+		}
+		data, _ := io.ReadAll(part)
+		if part.FileName() != "" {
+			fileType = part.Header.Get("Content-Type")
+			parts[part.FormName()] = part.FileName() + ":" + string(data)
+		} else {
+			parts[part.FormName()] = string(data)
+		}
+	}
+
+	want := map[string]string{
+		"data[type]":                      "activity-comment",
+		"data[attributes][message]":       "PoC attached",
+		"data[attributes][attachments][]": "poc.html:<html><script>alert(1)</script></html>",
+	}
+	if diff := cmp.Diff(want, parts); diff != "" {
+		t.Errorf("Comment() body mismatch (-want +got):\n%s", diff)
+	}
+	if fileType != "text/html; charset=utf-8" {
+		t.Errorf("Comment() attachment content type = %q, want text/html", fileType)
+	}
+}
+
+func TestHackerone_sendOnce_ClosesBody(t *testing.T) {
+	h1 := &Hackerone{token: "token", username: "username", client: &MockClient{}}
+
+	pr, pw := io.Pipe()
+	written := make(chan error, 1)
+	go func() {
+		_, err := pw.Write([]byte("never read"))
+		written <- err
+	}()
+
+	if _, _, err := h1.sendOnce("BAD METHOD", "https://api.hackerone.com/v1/hackers/reports/1337/activities", "text/plain", pr); err == nil {
+		t.Fatal("sendOnce() succeeded with an invalid method")
+	}
+	if err := <-written; err != io.ErrClosedPipe {
+		t.Errorf("pipe writer error = %v, want io.ErrClosedPipe", err)
+	}
+}
@@ -9,10 +9,16 @@ import (
 //
 // Username must be provided.
 // Token is optional, if not provided it will be read from ~/.config/h1_token.
+// RequestsPerMinute is optional, it defaults to DefaultRequestsPerMinute and a negative value disables rate limiting.
+// Client is optional and defaults to http.DefaultClient.
 type NewHackeroneInput struct {
 	Username string `json:"username"`
 
 	Token string `json:"token"`
+
+	RequestsPerMinute int `json:"requests_per_minute,omitempty"`
+
+	Client Client `json:"-"`
 }
 
 func NewHackerone(input *NewHackeroneInput) *Hackerone {
@@ -20,10 +26,19 @@ func NewHackerone(input *NewHackeroneInput) *Hackerone {
 		input.Token = GetH1Token()
 	}
 
+	if input.Client == nil {
+		input.Client = http.DefaultClient
+	}
+
+	if input.RequestsPerMinute == 0 {
+		input.RequestsPerMinute = DefaultRequestsPerMinute
+	}
+
 	return &Hackerone{
 		username: input.Username,
 		token:    strings.Trim(input.Token, " \t\n"),
-		client:   http.DefaultClient,
+		client:   input.Client,
+		limiter:  newRateLimiter(input.RequestsPerMinute),
 	}
 }
 
@@ -35,4 +50,5 @@ type Hackerone struct {
 	token    string
 	username string
 	client   Client
+	limiter  *rateLimiter
 }
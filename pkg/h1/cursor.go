@@ -0,0 +1,77 @@
+package h1
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Cursor is a position within a paginated listing.
+//
+// Next is the uri of the next page to fetch, Page is the number of pages that have been fully consumed. A cursor
+// with a Page but no Next belongs to a listing that has already finished.
+type Cursor struct {
+	Next string `json:"next"`
+	Page int    `json:"page"`
+}
+
+func (c *Cursor) done() bool { return c.Page > 0 && c.Next == "" }
+
+// LoadCursor reads a cursor checkpointed with SaveCursor. A missing file is not an error, it returns an empty
+// cursor so the listing starts from the first page.
+func LoadCursor(path string) (*Cursor, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &Cursor{}, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("LoadCursor: reading %s: %w", path, err)
+	}
+
+	cursor := Cursor{}
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, fmt.Errorf("LoadCursor: failed to unmarshal %s: %w", path, err)
+	}
+
+	return &cursor, nil
+}
+
+// SaveCursor checkpoints the cursor to path. The file is replaced atomically so a crash mid-write never leaves a
+// corrupt checkpoint behind.
+func SaveCursor(path string, cursor *Cursor) error {
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		return fmt.Errorf("SaveCursor: failed to marshal cursor: %w", err)
+	}
+
+	if err := writeFileAtomic(path, data); err != nil {
+		return fmt.Errorf("SaveCursor: %w", err)
+	}
+
+	return nil
+}
+
+// writeFileAtomic replaces the file at path with data through a temporary file and a rename, so readers never see a
+// partially written file.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("creating temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("writing %s: %w", tmp.Name(), err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("closing %s: %w", tmp.Name(), err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("renaming to %s: %w", path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,159 @@
+// Package customer is a client for the program side of the HackerOne API, used to manage the reports submitted to
+// your own programs. It shares the authentication, rate limiting and pagination of the h1 package.
+package customer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/ryanjarv/h1/pkg/h1"
+	"github.com/ryanjarv/h1/pkg/types"
+)
+
+const baseUri = "https://api.hackerone.com/v1"
+
+// States are the report states accepted by the customer API.
+var States = []string{
+	"new",
+	"pending-program-review",
+	"triaged",
+	"needs-more-info",
+	"resolved",
+	"not-applicable",
+	"informative",
+	"duplicate",
+	"spam",
+	"retesting",
+}
+
+// Severities are the severity ratings accepted by the customer API.
+var Severities = []string{"none", "low", "medium", "high", "critical"}
+
+// Customer is a client for the customer API, the credentials of the underlying client must be an API token of the
+// program's organization.
+type Customer struct {
+	h1 *h1.Hackerone
+}
+
+func New(client *h1.Hackerone) *Customer {
+	return &Customer{h1: client}
+}
+
+// ReportsInput is the input parameters for Reports.
+//
+// Program must be provided and is the handle of the program whose reports are listed.
+// States, Severities and Assignees are optional, a report matches when it matches any of the values. Assignees are
+// usernames or group names.
+// CreatedAfter and CreatedBefore are optional and bound the report's creation time.
+type ReportsInput struct {
+	Program string `json:"program"`
+
+	States []string `json:"states,omitempty"`
+
+	Severities []string `json:"severities,omitempty"`
+
+	Assignees []string `json:"assignees,omitempty"`
+
+	CreatedAfter *time.Time `json:"created_after,omitempty"`
+
+	CreatedBefore *time.Time `json:"created_before,omitempty"`
+
+	h1.ListInput
+}
+
+// query returns the filters of the input as query parameters.
+func (input *ReportsInput) query() (url.Values, error) {
+	if input == nil || input.Program == "" {
+		return nil, errors.New("Program is required")
+	}
+	if err := oneOf("state", input.States, States); err != nil {
+		return nil, err
+	}
+	if err := oneOf("severity", input.Severities, Severities); err != nil {
+		return nil, err
+	}
+
+	query := url.Values{
+		"filter[program][]":  {input.Program},
+		"filter[state][]":    input.States,
+		"filter[severity][]": input.Severities,
+		"filter[assignee][]": input.Assignees,
+	}
+	if input.CreatedAfter != nil {
+		query.Set("filter[created_at__gt]", input.CreatedAfter.UTC().Format(time.RFC3339))
+	}
+	if input.CreatedBefore != nil {
+		query.Set("filter[created_at__lt]", input.CreatedBefore.UTC().Format(time.RFC3339))
+	}
+
+	return query, nil
+}
+
+// Reports iterates over every report of a program matching the input's filters, filtering is done by the API.
+func (c *Customer) Reports(input *ReportsInput) iter.Seq2[types.CustomerReport, error] {
+	return func(yield func(types.CustomerReport, error) bool) {
+		query, err := input.query()
+		if err != nil {
+			yield(types.CustomerReport{}, fmt.Errorf("Reports: %w", err))
+			return
+		}
+
+		reports := h1.Paginate[types.CustomerReport](c.h1, &h1.PaginateInput{
+			Uri:       baseUri + "/reports?" + query.Encode(),
+			ListInput: input.ListInput,
+		})
+		for report, err := range reports {
+			if err != nil {
+				yield(report, fmt.Errorf("Reports: %w", err))
+				return
+			} else if !yield(report, nil) {
+				return
+			}
+		}
+	}
+}
+
+// Report returns a handle to one of the organization's reports, no request is made until one of its methods is
+// called.
+func (c *Customer) Report(id string) *Report {
+	return &Report{Customer: c, Id: id}
+}
+
+type Report struct {
+	*Customer `json:"-"`
+
+	Id string `json:"id"`
+}
+
+func (r *Report) uri() string {
+	return fmt.Sprintf("%s/reports/%s", baseUri, url.PathEscape(r.Id))
+}
+
+func (r *Report) GetDetail() (*types.CustomerReport, error) {
+	resp, _, err := r.h1.Send("GET", r.uri(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("GetDetail: getting report %s: %w", r.Id, err)
+	}
+
+	report := types.CustomerReport{}
+	if err := json.Unmarshal(resp, &report); err != nil {
+		return nil, fmt.Errorf("GetDetail: failed to unmarshal report %s: %w", r.Id, err)
+	}
+
+	return &report, nil
+}
+
+// oneOf checks that every value is one of allowed.
+func oneOf(name string, values, allowed []string) error {
+	for _, value := range values {
+		if !slices.Contains(allowed, value) {
+			return fmt.Errorf("unknown %s %q", name, value)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package customer
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/ryanjarv/h1/pkg/h1"
+)
+
+// mockClient records requests and responds with its responses in order.
+type mockClient struct {
+	responses []string
+	requests  []*http.Request
+	bodies    []string
+}
+
+func (m *mockClient) Do(req *http.Request) (*http.Response, error) {
+	body := ""
+	if req.Body != nil {
+		data, _ := io.ReadAll(req.Body)
+		body = string(data)
+	}
+	m.requests = append(m.requests, req)
+	m.bodies = append(m.bodies, body)
+
+	resp := `{}`
+	if len(m.responses) != 0 {
+		resp, m.responses = m.responses[0], m.responses[1:]
+	}
+	return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(resp)))}, nil
+}
+
+func newCustomer(client *mockClient) *Customer {
+	return New(h1.NewHackerone(&h1.NewHackeroneInput{
+		Username:          "api-user",
+		Token:             "token",
+		RequestsPerMinute: -1,
+		Client:            client,
+	}))
+}
+
+func TestCustomer_Reports(t *testing.T) {
+	client := &mockClient{responses: []string{
+		`{"data": [{"id": "1", "attributes": {"title": "XSS", "state": "triaged"}}], "links": {"next": "https://api.hackerone.com/v1/reports?filter%5Bprogram%5D%5B%5D=security&page%5Bnumber%5D=2"}}`,
+		`{"data": [{"id": "2", "attributes": {"title": "SQLi", "state": "new"}, "relationships": {"assignee": {"data": {"type": "group", "attributes": {"name": "Triage"}}}}}]}`,
+	}}
+	c := newCustomer(client)
+
+	after := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var got []string
+	for report, err := range c.Reports(&ReportsInput{
+		Program:      "security",
+		States:       []string{"new", "triaged"},
+		Severities:   []string{"high"},
+		Assignees:    []string{"Triage"},
+		CreatedAfter: &after,
+	}) {
+		if err != nil {
+			t.Fatalf("Reports() error = %v", err)
+		}
+		got = append(got, report.Id+":"+report.Attributes.State+":"+report.Relationships.Assignee.Data.Attributes.Name)
+	}
+
+	if diff := cmp.Diff([]string{"1:triaged:", "2:new:Triage"}, got); diff != "" {
+		t.Errorf("Reports() mismatch (-want +got):\n%s", diff)
+	}
+
+	query := client.requests[0].URL.Query()
+	want := map[string][]string{
+		"filter[program][]":      {"security"},
+		"filter[state][]":        {"new", "triaged"},
+		"filter[severity][]":     {"high"},
+		"filter[assignee][]":     {"Triage"},
+		"filter[created_at__gt]": {"2024-01-01T00:00:00Z"},
+	}
+	if diff := cmp.Diff(want, map[string][]string(query)); diff != "" {
+		t.Errorf("Reports() query mismatch (-want +got):\n%s", diff)
+	}
+	if user, _, _ := client.requests[0].BasicAuth(); user != "api-user" {
+		t.Errorf("Reports() authenticated as %q", user)
+	}
+}
+
+func TestCustomer_Reports_InvalidFilter(t *testing.T) {
+	client := &mockClient{}
+	c := newCustomer(client)
+
+	for _, input := range []*ReportsInput{
+		nil,
+		{},
+		{Program: "security", States: []string{"open"}},
+		{Program: "security", Severities: []string{"severe"}},
+	} {
+		for _, err := range c.Reports(input) {
+			if err == nil {
+				t.Errorf("Reports(%+v) succeeded", input)
+			}
+		}
+	}
+	if len(client.requests) != 0 {
+		t.Errorf("Reports() sent %d requests for invalid filters", len(client.requests))
+	}
+}
+
+func TestReport_GetDetail(t *testing.T) {
+	client := &mockClient{responses: []string{`{"id": "1337", "type": "report", "attributes": {"title": "XSS", "state": "triaged"}}`}}
+	c := newCustomer(client)
+
+	got, err := c.Report("1337").GetDetail()
+	if err != nil {
+		t.Fatalf("GetDetail() error = %v", err)
+	}
+	if got.Id != "1337" || got.Attributes.State != "triaged" {
+		t.Errorf("GetDetail() = %+v", got)
+	}
+	if path := client.requests[0].URL.Path; path != "/v1/reports/1337" {
+		t.Errorf("GetDetail() requested %s", path)
+	}
+}
@@ -0,0 +1,232 @@
+package customer
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ryanjarv/h1/pkg/types"
+)
+
+// ErrInvalidTransition is wrapped by the errors returned when a report can't be moved to the requested state.
+var ErrInvalidTransition = errors.New("invalid state transition")
+
+// closedStates are the states a report is closed in, closed reports can only be reopened.
+var closedStates = []string{"resolved", "not-applicable", "informative", "duplicate", "spam"}
+
+// transitions are the states a report can be moved to from each state. Retesting is requested through its own
+// endpoint and is never a target.
+var transitions = map[string][]string{
+	"new":                    {"pending-program-review", "triaged", "needs-more-info", "resolved", "not-applicable", "informative", "duplicate", "spam"},
+	"pending-program-review": {"new", "triaged", "needs-more-info", "resolved", "not-applicable", "informative", "duplicate", "spam"},
+	"triaged":                {"needs-more-info", "resolved", "not-applicable", "informative", "duplicate", "spam"},
+	"needs-more-info":        {"new", "triaged", "resolved", "not-applicable", "informative", "duplicate", "spam"},
+	"retesting":              {"triaged", "resolved"},
+	"resolved":               {"new", "triaged"},
+	"not-applicable":         {"new", "triaged"},
+	"informative":            {"new", "triaged"},
+	"duplicate":              {"new", "triaged"},
+	"spam":                   {"new", "triaged"},
+}
+
+// ValidateTransition checks that a report in state from can be moved to state to.
+func ValidateTransition(from, to string) error {
+	allowed, ok := transitions[from]
+	switch {
+	case !ok:
+		return fmt.Errorf("%w: unknown state %q", ErrInvalidTransition, from)
+	case !slices.Contains(States, to) || to == "retesting":
+		return fmt.Errorf("%w: unknown state %q", ErrInvalidTransition, to)
+	case from == to:
+		return fmt.Errorf("%w: report is already %s", ErrInvalidTransition, to)
+	case !slices.Contains(allowed, to) && slices.Contains(closedStates, from):
+		return fmt.Errorf("%w: %s reports can only be reopened as new or triaged", ErrInvalidTransition, from)
+	case !slices.Contains(allowed, to):
+		return fmt.Errorf("%w: %s reports can't be moved to %s", ErrInvalidTransition, from, to)
+	}
+	return nil
+}
+
+// ChangeState moves the report to state with message, which is posted to the reporter. The report's current state is
+// fetched first and the transition checked with ValidateTransition. Use Duplicate to close a report as a duplicate.
+func (r *Report) ChangeState(state, message string) (*types.CustomerReport, error) {
+	if state == "duplicate" {
+		return nil, fmt.Errorf("ChangeState: %w: use Duplicate to close a report as a duplicate", ErrInvalidTransition)
+	}
+
+	report, err := r.changeState(stateChangeAttributes{State: state, Message: message})
+	if err != nil {
+		return nil, fmt.Errorf("ChangeState: %w", err)
+	}
+	return report, nil
+}
+
+// Duplicate closes the report as a duplicate of the report original, after checking the transition like ChangeState.
+func (r *Report) Duplicate(original, message string) (*types.CustomerReport, error) {
+	originalId, err := strconv.Atoi(original)
+	if err != nil {
+		return nil, fmt.Errorf("Duplicate: original report id %q is not a number", original)
+	} else if original == r.Id {
+		return nil, fmt.Errorf("Duplicate: %w: report %s can't be a duplicate of itself", ErrInvalidTransition, r.Id)
+	}
+
+	report, err := r.changeState(stateChangeAttributes{State: "duplicate", Message: message, OriginalReportId: originalId})
+	if err != nil {
+		return nil, fmt.Errorf("Duplicate: %w", err)
+	}
+	return report, nil
+}
+
+type stateChangeAttributes struct {
+	State            string `json:"state"`
+	Message          string `json:"message,omitempty"`
+	OriginalReportId int    `json:"original_report_id,omitempty"`
+}
+
+func (r *Report) changeState(attributes stateChangeAttributes) (*types.CustomerReport, error) {
+	current, err := r.GetDetail()
+	if err != nil {
+		return nil, err
+	}
+	if err := ValidateTransition(current.Attributes.State, attributes.State); err != nil {
+		return nil, fmt.Errorf("report %s: %w", r.Id, err)
+	}
+
+	report := types.CustomerReport{}
+	if err := r.call("POST", "/state_changes", resource("state-change", "", attributes), &report); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// AssignToUser assigns the report to the user with the given id, message is an optional internal note.
+func (r *Report) AssignToUser(userId, message string) (*types.CustomerReport, error) {
+	report, err := r.assign("user", userId, message)
+	if err != nil {
+		return nil, fmt.Errorf("AssignToUser: %w", err)
+	}
+	return report, nil
+}
+
+// AssignToGroup assigns the report to the group with the given id, message is an optional internal note.
+func (r *Report) AssignToGroup(groupId, message string) (*types.CustomerReport, error) {
+	report, err := r.assign("group", groupId, message)
+	if err != nil {
+		return nil, fmt.Errorf("AssignToGroup: %w", err)
+	}
+	return report, nil
+}
+
+// Unassign removes the report's assignee, message is an optional internal note.
+func (r *Report) Unassign(message string) (*types.CustomerReport, error) {
+	report, err := r.assign("nobody", "", message)
+	if err != nil {
+		return nil, fmt.Errorf("Unassign: %w", err)
+	}
+	return report, nil
+}
+
+func (r *Report) assign(assigneeType, id, message string) (*types.CustomerReport, error) {
+	if assigneeType != "nobody" && id == "" {
+		return nil, fmt.Errorf("%s id is required", assigneeType)
+	}
+
+	attributes := struct {
+		Message string `json:"message,omitempty"`
+	}{Message: message}
+
+	report := types.CustomerReport{}
+	if err := r.call("PUT", "/assignee", resource(assigneeType, id, attributes), &report); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// Comment posts message to the report's timeline. Internal comments are only visible to the program's team, the
+// others are also visible to the reporter.
+func (r *Report) Comment(message string, internal bool) (*types.Activity, error) {
+	if strings.TrimSpace(message) == "" {
+		return nil, errors.New("Comment: message is required")
+	}
+
+	attributes := struct {
+		Message  string `json:"message"`
+		Internal bool   `json:"internal"`
+	}{Message: message, Internal: internal}
+
+	activity := types.Activity{}
+	if err := r.call("POST", "/activities", resource("activity-comment", "", attributes), &activity); err != nil {
+		return nil, fmt.Errorf("Comment: %w", err)
+	}
+	return &activity, nil
+}
+
+// SetSeverity sets the report's severity rating, one of Severities.
+func (r *Report) SetSeverity(rating string) (*types.Severity, error) {
+	rating = strings.ToLower(rating)
+	if err := oneOf("severity", []string{rating}, Severities); err != nil {
+		return nil, fmt.Errorf("SetSeverity: %w", err)
+	}
+
+	attributes := struct {
+		Rating string `json:"rating"`
+	}{Rating: rating}
+
+	severity := types.Severity{}
+	if err := r.call("POST", "/severities", resource("severity", "", attributes), &severity); err != nil {
+		return nil, fmt.Errorf("SetSeverity: %w", err)
+	}
+	return &severity, nil
+}
+
+// SetWeakness links the weakness with the given id to the report.
+func (r *Report) SetWeakness(weaknessId string) (*types.CustomerReport, error) {
+	id, err := strconv.Atoi(weaknessId)
+	if err != nil {
+		return nil, fmt.Errorf("SetWeakness: weakness id %q is not a number", weaknessId)
+	}
+
+	attributes := struct {
+		WeaknessId int `json:"weakness_id"`
+	}{WeaknessId: id}
+
+	report := types.CustomerReport{}
+	if err := r.call("PUT", "/weakness", resource("weakness", "", attributes), &report); err != nil {
+		return nil, fmt.Errorf("SetWeakness: %w", err)
+	}
+	return &report, nil
+}
+
+// resource wraps attributes in a JSON:API request document.
+func resource(resourceType, id string, attributes any) any {
+	type data struct {
+		Id         string `json:"id,omitempty"`
+		Type       string `json:"type"`
+		Attributes any    `json:"attributes"`
+	}
+	return struct {
+		Data data `json:"data"`
+	}{Data: data{Id: id, Type: resourceType, Attributes: attributes}}
+}
+
+// call sends payload to the report's sub resource at path and unmarshals the response into out.
+func (r *Report) call(method, path string, payload, out any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	resp, _, err := r.h1.Send(method, r.uri()+path, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("report %s: %w", r.Id, err)
+	}
+
+	if err := json.Unmarshal(resp, out); err != nil {
+		return fmt.Errorf("report %s: failed to unmarshal response: %w", r.Id, err)
+	}
+	return nil
+}
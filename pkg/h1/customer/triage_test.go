@@ -0,0 +1,203 @@
+package customer
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestValidateTransition(t *testing.T) {
+	tests := []struct {
+		from, to string
+		wantErr  bool
+	}{
+		{"new", "triaged", false},
+		{"triaged", "resolved", false},
+		{"resolved", "triaged", false},
+		{"triaged", "triaged", true},
+		{"triaged", "new", true},
+		{"resolved", "informative", true},
+		{"new", "retesting", true},
+		{"new", "open", true},
+		{"open", "new", true},
+	}
+
+	for _, tt := range tests {
+		err := ValidateTransition(tt.from, tt.to)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ValidateTransition(%s, %s) error = %v, wantErr %v", tt.from, tt.to, err, tt.wantErr)
+		} else if err != nil && !errors.Is(err, ErrInvalidTransition) {
+			t.Errorf("ValidateTransition(%s, %s) error = %v, want ErrInvalidTransition", tt.from, tt.to, err)
+		}
+	}
+}
+
+func TestReport_Triage(t *testing.T) {
+	report := func(state string) string {
+		return `{"id": "1337", "type": "report", "attributes": {"state": "` + state + `"}}`
+	}
+
+	tests := []struct {
+		name         string
+		responses    []string
+		call         func(r *Report) error
+		wantErr      error
+		wantRequests int
+		wantMethod   string
+		wantPath     string
+		wantBody     string
+	}{
+		{
+			name:      "change state",
+			responses: []string{report("new"), report("triaged")},
+			call: func(r *Report) error {
+				_, err := r.ChangeState("triaged", "Thanks, confirmed.")
+				return err
+			},
+			wantRequests: 2,
+			wantMethod:   "POST",
+			wantPath:     "/v1/reports/1337/state_changes",
+			wantBody:     `{"data": {"type": "state-change", "attributes": {"state": "triaged", "message": "Thanks, confirmed."}}}`,
+		},
+		{
+			name:      "invalid transition is rejected before calling the API",
+			responses: []string{report("resolved")},
+			call: func(r *Report) error {
+				_, err := r.ChangeState("needs-more-info", "")
+				return err
+			},
+			wantErr:      ErrInvalidTransition,
+			wantRequests: 1,
+		},
+		{
+			name:      "duplicate",
+			responses: []string{report("triaged"), report("duplicate")},
+			call: func(r *Report) error {
+				_, err := r.Duplicate("1000", "Duplicate of an earlier report.")
+				return err
+			},
+			wantRequests: 2,
+			wantMethod:   "POST",
+			wantPath:     "/v1/reports/1337/state_changes",
+			wantBody:     `{"data": {"type": "state-change", "attributes": {"state": "duplicate", "message": "Duplicate of an earlier report.", "original_report_id": 1000}}}`,
+		},
+		{
+			name: "duplicate of itself",
+			call: func(r *Report) error {
+				_, err := r.Duplicate("1337", "")
+				return err
+			},
+			wantErr: ErrInvalidTransition,
+		},
+		{
+			name:      "assign to group",
+			responses: []string{report("triaged")},
+			call: func(r *Report) error {
+				_, err := r.AssignToGroup("42", "Routing to the web team.")
+				return err
+			},
+			wantRequests: 1,
+			wantMethod:   "PUT",
+			wantPath:     "/v1/reports/1337/assignee",
+			wantBody:     `{"data": {"id": "42", "type": "group", "attributes": {"message": "Routing to the web team."}}}`,
+		},
+		{
+			name:      "unassign",
+			responses: []string{report("triaged")},
+			call: func(r *Report) error {
+				_, err := r.Unassign("")
+				return err
+			},
+			wantRequests: 1,
+			wantMethod:   "PUT",
+			wantPath:     "/v1/reports/1337/assignee",
+			wantBody:     `{"data": {"type": "nobody", "attributes": {}}}`,
+		},
+		{
+			name:      "internal comment",
+			responses: []string{`{"id": "1", "type": "activity-comment"}`},
+			call: func(r *Report) error {
+				_, err := r.Comment("Reproduced on staging.", true)
+				return err
+			},
+			wantRequests: 1,
+			wantMethod:   "POST",
+			wantPath:     "/v1/reports/1337/activities",
+			wantBody:     `{"data": {"type": "activity-comment", "attributes": {"message": "Reproduced on staging.", "internal": true}}}`,
+		},
+		{
+			name:      "severity",
+			responses: []string{`{"id": "1", "type": "severity", "attributes": {"rating": "high"}}`},
+			call: func(r *Report) error {
+				_, err := r.SetSeverity("High")
+				return err
+			},
+			wantRequests: 1,
+			wantMethod:   "POST",
+			wantPath:     "/v1/reports/1337/severities",
+			wantBody:     `{"data": {"type": "severity", "attributes": {"rating": "high"}}}`,
+		},
+		{
+			name: "unknown severity",
+			call: func(r *Report) error {
+				_, err := r.SetSeverity("severe")
+				return err
+			},
+			wantErr: errAny,
+		},
+		{
+			name:      "weakness",
+			responses: []string{report("triaged")},
+			call: func(r *Report) error {
+				_, err := r.SetWeakness("60")
+				return err
+			},
+			wantRequests: 1,
+			wantMethod:   "PUT",
+			wantPath:     "/v1/reports/1337/weakness",
+			wantBody:     `{"data": {"type": "weakness", "attributes": {"weakness_id": 60}}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &mockClient{responses: tt.responses}
+			err := tt.call(newCustomer(client).Report("1337"))
+
+			switch {
+			case tt.wantErr == errAny && err == nil:
+				t.Fatal("call succeeded, want an error")
+			case tt.wantErr != nil && tt.wantErr != errAny && !errors.Is(err, tt.wantErr):
+				t.Fatalf("call error = %v, want %v", err, tt.wantErr)
+			case tt.wantErr == nil && err != nil:
+				t.Fatalf("call error = %v", err)
+			}
+			if len(client.requests) != tt.wantRequests {
+				t.Fatalf("sent %d requests, want %d", len(client.requests), tt.wantRequests)
+			}
+			if tt.wantMethod == "" {
+				return
+			}
+
+			last := client.requests[len(client.requests)-1]
+			if last.Method != tt.wantMethod || last.URL.Path != tt.wantPath {
+				t.Errorf("sent %s %s, want %s %s", last.Method, last.URL.Path, tt.wantMethod, tt.wantPath)
+			}
+			var got, want any
+			if err := json.Unmarshal([]byte(client.bodies[len(client.bodies)-1]), &got); err != nil {
+				t.Fatalf("decoding request body: %s", err)
+			}
+			if err := json.Unmarshal([]byte(tt.wantBody), &want); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("request body mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// errAny matches any error in table tests.
+var errAny = errors.New("any error")
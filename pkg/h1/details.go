@@ -0,0 +1,74 @@
+package h1
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ryanjarv/h1/pkg/types"
+)
+
+// DetailResult is the outcome of fetching a single program's detail, exactly one of Detail and Err is set.
+type DetailResult struct {
+	Handle string
+	Detail *types.ProgramDetail
+	Err    error
+}
+
+// DetailsFor fetches the detail of every program handle using at most concurrency requests at a time, all of which
+// still go through the client's rate limiter.
+//
+// A failed program doesn't abort the batch, results are returned in the same order as handles and the returned
+// error joins every failure. onProgress is optional and is called after each program with the number done so far,
+// calls are serialized so it doesn't need to be safe for concurrent use.
+func (h1 *Hackerone) DetailsFor(handles []string, concurrency int, onProgress func(done, total int)) ([]DetailResult, error) {
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	results := make([]DetailResult, len(handles))
+	jobs := make(chan int)
+
+	var mu sync.Mutex
+	done := 0
+
+	var wg sync.WaitGroup
+	for range min(concurrency, len(handles)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				detail, err := h1.Program(handles[i]).GetDetail()
+				if err != nil {
+					err = fmt.Errorf("%s: %w", handles[i], err)
+				}
+				results[i] = DetailResult{Handle: handles[i], Detail: detail, Err: err}
+
+				mu.Lock()
+				done++
+				if onProgress != nil {
+					onProgress(done, len(handles))
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for i := range handles {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	var errs []error
+	for _, result := range results {
+		if result.Err != nil {
+			errs = append(errs, result.Err)
+		}
+	}
+	if len(errs) != 0 {
+		return results, fmt.Errorf("DetailsFor: %d of %d programs failed: %w", len(errs), len(handles), errors.Join(errs...))
+	}
+
+	return results, nil
+}
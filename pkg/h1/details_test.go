@@ -0,0 +1,72 @@
+package h1
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"path"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// clientFunc responds to requests based on their contents, for tests where the order of requests isn't fixed.
+type clientFunc func(req *http.Request) (*http.Response, error)
+
+func (f clientFunc) Do(req *http.Request) (*http.Response, error) { return f(req) }
+
+func TestHackerone_DetailsFor(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int32
+	client := clientFunc(func(req *http.Request) (*http.Response, error) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			m := maxInFlight.Load()
+			if n <= m || maxInFlight.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		handle := path.Base(req.URL.Path)
+		if handle == "missing" {
+			return &http.Response{StatusCode: 404, Status: "404 Not Found", Body: io.NopCloser(bytes.NewReader(nil))}, nil
+		}
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(bytes.NewReader([]byte(`{"id": "1", "attributes": {"handle": "` + handle + `"}}`))),
+		}, nil
+	})
+	h1 := &Hackerone{token: "token", username: "username", client: client}
+
+	handles := []string{"a", "b", "missing", "c", "d", "e"}
+	var progress []int
+	results, err := h1.DetailsFor(handles, 2, func(done, total int) {
+		if total != len(handles) {
+			t.Errorf("onProgress() total = %d, want %d", total, len(handles))
+		}
+		progress = append(progress, done)
+	})
+
+	if err == nil {
+		t.Errorf("DetailsFor() error = nil, want the missing program's error")
+	}
+	for i, result := range results {
+		if result.Handle != handles[i] {
+			t.Errorf("DetailsFor() result %d is for %s, want %s", i, result.Handle, handles[i])
+		}
+		if result.Handle == "missing" {
+			if result.Err == nil {
+				t.Errorf("DetailsFor() missing program has no error")
+			}
+		} else if result.Err != nil || result.Detail.Attributes.Handle != result.Handle {
+			t.Errorf("DetailsFor() result %d = %+v, want the detail of %s", i, result, handles[i])
+		}
+	}
+	if len(progress) != len(handles) || progress[len(progress)-1] != len(handles) {
+		t.Errorf("onProgress() called with %v, want 1 through %d", progress, len(handles))
+	}
+	if maxInFlight.Load() > 2 {
+		t.Errorf("DetailsFor() made %d concurrent requests, want at most 2", maxInFlight.Load())
+	}
+}
@@ -0,0 +1,24 @@
+package h1
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/ryanjarv/h1/pkg/types"
+)
+
+// GetDocument fetches uri as a generic JSON:API document, for endpoints that don't have a method of their own. Only
+// the requested page is fetched, the document's links point to the others.
+func (h1 *Hackerone) GetDocument(uri string) (*types.Document, error) {
+	resp, _, err := h1.send("GET", uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("GetDocument: %w", err)
+	}
+
+	doc := types.Document{}
+	if err := json.Unmarshal(resp, &doc); err != nil {
+		return nil, fmt.Errorf("GetDocument: failed to unmarshal %s: %w", uri, err)
+	}
+
+	return &doc, nil
+}
@@ -0,0 +1,38 @@
+package h1
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/ryanjarv/h1/pkg/types"
+)
+
+func TestHackerone_GetDocument(t *testing.T) {
+	mockClient := &MockClient{DoResponse: []*http.Response{{
+		StatusCode: 200,
+		Body: io.NopCloser(bytes.NewReader([]byte(`{
+  "data": {"id": "1", "type": "structured-scope", "relationships": {"weakness": {"data": {"id": "60", "type": "weakness"}}}},
+  "included": [{"id": "60", "type": "weakness", "attributes": {"external_id": "cwe-79"}}]
+}`))),
+	}}}
+	h1 := &Hackerone{token: "token", username: "username", client: mockClient}
+
+	doc, err := h1.GetDocument("https://api.hackerone.com/v1/hackers/programs/security/structured_scopes/1")
+	if err != nil {
+		t.Fatalf("GetDocument() error = %v", err)
+	}
+
+	scope := doc.Data.One()
+	if scope == nil || doc.Data.Many {
+		t.Fatalf("GetDocument() data = %+v, want a single resource", doc.Data)
+	}
+	weakness := doc.Related(scope, "weakness")
+	if len(weakness) != 1 {
+		t.Fatalf("Related() = %v", weakness)
+	}
+	if attrs, _ := types.Attributes[types.WeaknessAttributes](weakness[0]); attrs.ExternalId != "cwe-79" {
+		t.Errorf("Related() attributes = %+v, want the included weakness", attrs)
+	}
+}
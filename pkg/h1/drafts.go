@@ -0,0 +1,301 @@
+package h1
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"time"
+)
+
+const (
+	draftFile         = "draft.json"
+	draftMarker       = "submitting"
+	draftAttachments  = "attachments"
+	draftDirPrefix    = "draft-"
+	draftDirTimestamp = "20060102-150405"
+)
+
+// StoredDraft is a report draft queued in a DraftStore.
+//
+// ReportId is set once the draft has been submitted, LastError holds why the last flush failed to submit it.
+type StoredDraft struct {
+	Id string `json:"-"`
+
+	Program string `json:"program"`
+
+	Draft ReportDraft `json:"draft"`
+
+	CreatedAt time.Time `json:"created_at"`
+
+	SubmittedAt *time.Time `json:"submitted_at,omitempty"`
+
+	ReportId string `json:"report_id,omitempty"`
+
+	LastError string `json:"last_error,omitempty"`
+}
+
+// DraftStore queues report drafts on disk so they can be written offline and submitted later with FlushDrafts.
+//
+// Every draft is a directory under Dir holding draft.json and a copy of its attachments, so the original files can
+// be moved or deleted once the draft is saved.
+type DraftStore struct {
+	Dir string
+}
+
+func NewDraftStore(dir string) (*DraftStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("NewDraftStore: %w", err)
+	}
+	return &DraftStore{Dir: dir}, nil
+}
+
+// Save checks the draft with Validate and queues it for program, copying its attachments into the store.
+func (s *DraftStore) Save(program string, draft *ReportDraft) (*StoredDraft, error) {
+	if err := draft.Validate(); err != nil {
+		return nil, fmt.Errorf("Save: %w", err)
+	}
+
+	dir, err := os.MkdirTemp(s.Dir, draftDirPrefix+time.Now().UTC().Format(draftDirTimestamp)+"-")
+	if err != nil {
+		return nil, fmt.Errorf("Save: %w", err)
+	}
+
+	stored := &StoredDraft{Id: filepath.Base(dir), Program: program, Draft: *draft, CreatedAt: time.Now().UTC()}
+	stored.Draft.Attachments = nil
+	for i, path := range draft.Attachments {
+		name := filepath.Base(path)
+		if slices.Contains(stored.Draft.Attachments, name) {
+			name = strconv.Itoa(i) + "-" + name
+		}
+		if err := copyFile(path, filepath.Join(dir, draftAttachments, name)); err != nil {
+			os.RemoveAll(dir)
+			return nil, fmt.Errorf("Save: attachment %s: %w", path, err)
+		}
+		stored.Draft.Attachments = append(stored.Draft.Attachments, name)
+	}
+
+	if err := s.write(stored); err != nil {
+		os.RemoveAll(dir)
+		return nil, fmt.Errorf("Save: %w", err)
+	}
+
+	return s.Get(stored.Id)
+}
+
+// Get reads a queued draft, the paths of its attachments point into the store.
+func (s *DraftStore) Get(id string) (*StoredDraft, error) {
+	dir := filepath.Join(s.Dir, id)
+	data, err := os.ReadFile(filepath.Join(dir, draftFile))
+	if err != nil {
+		return nil, fmt.Errorf("Get: reading draft %s: %w", id, err)
+	}
+
+	stored := StoredDraft{}
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return nil, fmt.Errorf("Get: failed to unmarshal draft %s: %w", id, err)
+	}
+	stored.Id = id
+	for i, name := range stored.Draft.Attachments {
+		stored.Draft.Attachments[i] = filepath.Join(dir, draftAttachments, name)
+	}
+
+	return &stored, nil
+}
+
+// List returns every draft in the store, including submitted ones, oldest first.
+func (s *DraftStore) List() ([]*StoredDraft, error) {
+	entries, err := os.ReadDir(s.Dir)
+	if err != nil {
+		return nil, fmt.Errorf("List: %w", err)
+	}
+
+	var drafts []*StoredDraft
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		stored, err := s.Get(entry.Name())
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("List: %w", err)
+		}
+		drafts = append(drafts, stored)
+	}
+
+	slices.SortStableFunc(drafts, func(a, b *StoredDraft) int { return a.CreatedAt.Compare(b.CreatedAt) })
+	return drafts, nil
+}
+
+// Remove deletes a draft and its attachments from the store.
+func (s *DraftStore) Remove(id string) error {
+	if err := os.RemoveAll(filepath.Join(s.Dir, id)); err != nil {
+		return fmt.Errorf("Remove: %w", err)
+	}
+	return nil
+}
+
+// write persists the draft's metadata, attachments are stored by file name relative to the draft directory.
+func (s *DraftStore) write(stored *StoredDraft) error {
+	relative := *stored
+	relative.Draft.Attachments = make([]string, len(stored.Draft.Attachments))
+	for i, path := range stored.Draft.Attachments {
+		relative.Draft.Attachments[i] = filepath.Base(path)
+	}
+
+	data, err := json.MarshalIndent(&relative, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal draft %s: %w", stored.Id, err)
+	}
+	if err := writeFileAtomic(filepath.Join(s.Dir, stored.Id, draftFile), data); err != nil {
+		return fmt.Errorf("saving draft %s: %w", stored.Id, err)
+	}
+
+	return nil
+}
+
+// FlushResult is the outcome of flushing a single draft. ReportId is set when the draft was submitted, Err when it
+// was not. Drafts that had already been submitted are not part of the results.
+type FlushResult struct {
+	Id       string
+	Program  string
+	ReportId string
+	Err      error
+}
+
+// FlushDrafts validates every pending draft against the program's current scopes and weaknesses with ValidateFor,
+// submits it and records the resulting report id in the draft.
+//
+// A draft is never submitted twice: a marker file is created once the draft is validated, right before submitting,
+// and only removed once the report id is recorded or the API rejected the draft. If a previous flush was interrupted
+// after sending a draft, the marker is left behind and the draft is reported as failed until it is checked manually
+// and the marker removed. Drafts submitted by a concurrent flush since they were listed are skipped. Drafts that fail
+// validation, e.g. because the API can't be reached, are left pending.
+//
+// A failed draft doesn't abort the flush, the returned error joins every failure.
+func (h1 *Hackerone) FlushDrafts(store *DraftStore) ([]FlushResult, error) {
+	drafts, err := store.List()
+	if err != nil {
+		return nil, fmt.Errorf("FlushDrafts: %w", err)
+	}
+
+	programs := map[string]*Program{}
+
+	var results []FlushResult
+	var errs []error
+	for _, stored := range drafts {
+		if stored.ReportId != "" {
+			continue
+		}
+
+		program, ok := programs[stored.Program]
+		if !ok {
+			program = h1.Program(stored.Program)
+			programs[stored.Program] = program
+		}
+
+		result := FlushResult{Id: stored.Id, Program: stored.Program}
+		result.ReportId, result.Err = store.submit(program, stored)
+		if errors.Is(result.Err, errAlreadySubmitted) {
+			continue
+		} else if result.Err != nil {
+			result.Err = fmt.Errorf("draft %s: %w", stored.Id, result.Err)
+			errs = append(errs, result.Err)
+		}
+		results = append(results, result)
+	}
+
+	if len(errs) != 0 {
+		return results, fmt.Errorf("FlushDrafts: %d of %d drafts failed: %w", len(errs), len(results), errors.Join(errs...))
+	}
+
+	return results, nil
+}
+
+// errAlreadySubmitted is returned by submit when the draft was submitted by another flush since it was listed.
+var errAlreadySubmitted = errors.New("draft was already submitted")
+
+// submit submits a single draft guarded by its marker file and records the outcome in the draft.
+func (s *DraftStore) submit(program *Program, stored *StoredDraft) (string, error) {
+	marker := filepath.Join(s.Dir, stored.Id, draftMarker)
+	if _, err := os.Stat(marker); err == nil {
+		return "", fmt.Errorf("a previous submission was interrupted, check whether report was created and remove %s", marker)
+	}
+
+	// Validation only reads from the API, so failing here, e.g. while offline, never blocks the draft.
+	if err := stored.Draft.ValidateFor(program); err != nil {
+		stored.LastError = err.Error()
+		if writeErr := s.write(stored); writeErr != nil {
+			return "", errors.Join(err, writeErr)
+		}
+		return "", err
+	}
+
+	f, err := os.OpenFile(marker, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if errors.Is(err, os.ErrExist) {
+		return "", fmt.Errorf("a previous submission was interrupted, check whether report was created and remove %s", marker)
+	} else if err != nil {
+		return "", fmt.Errorf("creating %s: %w", marker, err)
+	}
+	f.Close()
+
+	// Another flush may have submitted the draft since it was listed and removed its marker before ours was created.
+	current, err := s.Get(stored.Id)
+	if err != nil {
+		os.Remove(marker)
+		return "", err
+	} else if current.ReportId != "" {
+		os.Remove(marker)
+		return current.ReportId, errAlreadySubmitted
+	}
+
+	report, err := program.submitReport(&stored.Draft)
+	if err != nil {
+		// The report was definitely not created when the API rejected it, otherwise the request may have gone through
+		// and the marker is kept.
+		if code := statusCode(err); code >= 400 && code < 500 {
+			stored.LastError = err.Error()
+			if writeErr := s.write(stored); writeErr != nil {
+				return "", errors.Join(err, writeErr)
+			}
+			os.Remove(marker)
+		}
+		return "", err
+	}
+
+	now := time.Now().UTC()
+	stored.ReportId, stored.SubmittedAt, stored.LastError = report.Id, &now, ""
+	if err := s.write(stored); err != nil {
+		return report.Id, fmt.Errorf("report %s was submitted but recording it failed: %w", report.Id, err)
+	}
+	os.Remove(marker)
+
+	return report.Id, nil
+}
+
+// copyFile copies src to dst, creating dst's directory.
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	if err := os.MkdirAll(filepath.Dir(dst), 0o700); err != nil {
+		return err
+	}
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,197 @@
+package h1
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"testing"
+)
+
+func TestHackerone_FlushDrafts(t *testing.T) {
+	var submitted, scopeRequests atomic.Int32
+	client := clientFunc(func(req *http.Request) (*http.Response, error) {
+		body := `{"data": []}`
+		switch {
+		case req.Method == "POST":
+			submitted.Add(1)
+			body = `{"id": "1337", "type": "report"}`
+		case strings.HasSuffix(req.URL.Path, "/weaknesses"):
+			body = `{"data": [{"id": "60"}]}`
+		case strings.HasSuffix(req.URL.Path, "/structured_scopes"):
+			scopeRequests.Add(1)
+			body = `{"data": [{"id": "1", "attributes": {"eligible_for_submission": true}}]}`
+		}
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(body)))}, nil
+	})
+	h1 := &Hackerone{token: "token", username: "username", client: client}
+
+	dir := t.TempDir()
+	attachment := filepath.Join(dir, "poc.txt")
+	if err := os.WriteFile(attachment, []byte("proof of concept"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err := NewDraftStore(filepath.Join(dir, "drafts"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	draft := func() *ReportDraft {
+		return NewReportDraft("XSS in search").
+			WithVulnerabilityInformation("The q parameter is reflected unescaped.").
+			WithImpact("Session hijacking.").
+			WithStructuredScope("1")
+	}
+
+	valid, err := store.Save("security", draft().WithWeakness("60").WithAttachment(attachment))
+	if err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	invalid, err := store.Save("security", draft().WithWeakness("61"))
+	if err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if _, err := store.Save("security", NewReportDraft("")); !errors.Is(err, ErrInvalidDraft) {
+		t.Errorf("Save() error = %v, want ErrInvalidDraft", err)
+	}
+
+	// The attachment is copied into the store, so the original can go away before flushing.
+	if err := os.Remove(attachment); err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := h1.FlushDrafts(store)
+	if !errors.Is(err, ErrInvalidDraft) {
+		t.Errorf("FlushDrafts() error = %v, want ErrInvalidDraft", err)
+	}
+	if len(results) != 2 || results[0].Id != valid.Id || results[0].ReportId != "1337" || results[1].Id != invalid.Id || results[1].Err == nil {
+		t.Errorf("FlushDrafts() = %+v", results)
+	}
+
+	got, err := store.Get(valid.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.ReportId != "1337" || got.SubmittedAt == nil {
+		t.Errorf("Get() = %+v, want the report id recorded", got)
+	}
+	got, err = store.Get(invalid.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.ReportId != "" || got.LastError == "" {
+		t.Errorf("Get() = %+v, want the validation error recorded", got)
+	}
+
+	// Submitted drafts are skipped, drafts left behind by an interrupted submission are not retried.
+	if err := os.WriteFile(filepath.Join(store.Dir, invalid.Id, draftMarker), nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	results, err = h1.FlushDrafts(store)
+	if err == nil || len(results) != 1 || results[0].Id != invalid.Id {
+		t.Errorf("FlushDrafts() = %+v, %v", results, err)
+	}
+	if n := submitted.Load(); n != 1 {
+		t.Errorf("submitted %d reports, want 1", n)
+	}
+	// Both drafts were validated against the program's cached scopes.
+	if n := scopeRequests.Load(); n != 1 {
+		t.Errorf("fetched structured scopes %d times, want 1", n)
+	}
+}
+
+func TestHackerone_FlushDrafts_Offline(t *testing.T) {
+	var offline atomic.Bool
+	offline.Store(true)
+	var submitted atomic.Int32
+	client := clientFunc(func(req *http.Request) (*http.Response, error) {
+		if offline.Load() {
+			return nil, &url.Error{Op: "Get", URL: req.URL.String(), Err: syscall.ENETUNREACH}
+		}
+		body := `{"data": [{"id": "60"}]}`
+		if req.Method == "POST" {
+			submitted.Add(1)
+			body = `{"id": "1337", "type": "report"}`
+		}
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(body)))}, nil
+	})
+	h1 := &Hackerone{token: "token", username: "username", client: client}
+
+	store, err := NewDraftStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	stored, err := store.Save("security", NewReportDraft("XSS in search").
+		WithVulnerabilityInformation("The q parameter is reflected unescaped.").
+		WithImpact("Session hijacking.").
+		WithWeakness("60"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Fetching the weaknesses to validate the draft fails, nothing was submitted so the draft stays pending.
+	if _, err := h1.FlushDrafts(store); err == nil {
+		t.Fatal("FlushDrafts() succeeded while offline")
+	}
+	if _, err := os.Stat(filepath.Join(store.Dir, stored.Id, draftMarker)); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("marker left behind after a failed validation: %v", err)
+	}
+
+	offline.Store(false)
+	results, err := h1.FlushDrafts(store)
+	if err != nil {
+		t.Fatalf("FlushDrafts() error = %v", err)
+	}
+	if len(results) != 1 || results[0].ReportId != "1337" {
+		t.Errorf("FlushDrafts() = %+v", results)
+	}
+	if n := submitted.Load(); n != 1 {
+		t.Errorf("submitted %d reports, want 1", n)
+	}
+}
+
+func TestDraftStore_submit_Concurrent(t *testing.T) {
+	var submitted atomic.Int32
+	client := clientFunc(func(req *http.Request) (*http.Response, error) {
+		body := `{"data": [{"id": "60"}]}`
+		if req.Method == "POST" {
+			submitted.Add(1)
+			body = `{"id": "1337", "type": "report"}`
+		}
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(body)))}, nil
+	})
+	h1 := &Hackerone{token: "token", username: "username", client: client}
+
+	store, err := NewDraftStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	stale, err := store.Save("security", NewReportDraft("XSS in search").
+		WithVulnerabilityInformation("The q parameter is reflected unescaped.").
+		WithImpact("Session hijacking.").
+		WithWeakness("60"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := h1.FlushDrafts(store); err != nil {
+		t.Fatalf("FlushDrafts() error = %v", err)
+	}
+
+	// A second flush that listed the draft before the first one recorded its report id must not submit it again.
+	if id, err := store.submit(h1.Program("security"), stale); !errors.Is(err, errAlreadySubmitted) || id != "1337" {
+		t.Errorf("submit() = %q, %v, want errAlreadySubmitted", id, err)
+	}
+	if n := submitted.Load(); n != 1 {
+		t.Errorf("submitted %d reports, want 1", n)
+	}
+	if _, err := os.Stat(filepath.Join(store.Dir, stale.Id, draftMarker)); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("marker left behind for an already submitted draft: %v", err)
+	}
+}
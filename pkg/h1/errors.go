@@ -0,0 +1,32 @@
+package h1
+
+import (
+	"errors"
+	"fmt"
+)
+
+// StatusError is returned when the API responds with a non-2xx status code.
+type StatusError struct {
+	Uri        string
+	StatusCode int
+	Status     string
+	Body       []byte
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("api call failed: %s returned %s", e.Uri, e.Status)
+}
+
+// Temporary reports whether the request may succeed if it is retried later.
+func (e *StatusError) Temporary() bool {
+	return e.StatusCode == 429 || e.StatusCode >= 500
+}
+
+// statusCode returns the status code of a StatusError wrapped in err, or 0 if there is none.
+func statusCode(err error) int {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode
+	}
+	return 0
+}
@@ -0,0 +1,146 @@
+package h1
+
+import (
+	"fmt"
+	"iter"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ryanjarv/h1/pkg/types"
+)
+
+// HacktivityQuery builds the Lucene style queryString accepted by the hacktivity endpoint, every condition added is
+// combined with AND. Conditions that take several values match any of them.
+//
+// The zero value matches everything, use NewHacktivityQuery and the chained methods to narrow it down.
+type HacktivityQuery struct {
+	terms []string
+}
+
+func NewHacktivityQuery() *HacktivityQuery {
+	return &HacktivityQuery{}
+}
+
+// Severity matches items rated with any of the given severities, such as critical or high.
+func (q *HacktivityQuery) Severity(ratings ...string) *HacktivityQuery {
+	return q.anyOf("severity_rating", ratings)
+}
+
+// Disclosed matches disclosed items when true and undisclosed ones when false.
+func (q *HacktivityQuery) Disclosed(disclosed bool) *HacktivityQuery {
+	return q.add("disclosed:" + strconv.FormatBool(disclosed))
+}
+
+// Program matches items reported to any of the given program handles.
+func (q *HacktivityQuery) Program(handles ...string) *HacktivityQuery {
+	return q.anyOf("team", handles)
+}
+
+// Weakness matches items classified as any of the given weaknesses, by name or CWE id such as CWE-79.
+func (q *HacktivityQuery) Weakness(weaknesses ...string) *HacktivityQuery {
+	return q.anyOf("cwe", weaknesses)
+}
+
+// DisclosedBetween matches items disclosed within the range, a zero time leaves that end of the range open.
+func (q *HacktivityQuery) DisclosedBetween(from, to time.Time) *HacktivityQuery {
+	return q.between("disclosed_at", from, to)
+}
+
+// SubmittedBetween matches items submitted within the range, a zero time leaves that end of the range open.
+func (q *HacktivityQuery) SubmittedBetween(from, to time.Time) *HacktivityQuery {
+	return q.between("submitted_at", from, to)
+}
+
+// MinBounty matches items awarded at least amount in total.
+func (q *HacktivityQuery) MinBounty(amount float64) *HacktivityQuery {
+	return q.add("total_awarded_amount:>=" + strconv.FormatFloat(amount, 'f', -1, 64))
+}
+
+// MaxBounty matches items awarded at most amount in total.
+func (q *HacktivityQuery) MaxBounty(amount float64) *HacktivityQuery {
+	return q.add("total_awarded_amount:<=" + strconv.FormatFloat(amount, 'f', -1, 64))
+}
+
+// String returns the queryString, an empty query matches everything.
+func (q *HacktivityQuery) String() string {
+	if q == nil {
+		return ""
+	}
+	return strings.Join(q.terms, " AND ")
+}
+
+func (q *HacktivityQuery) add(term string) *HacktivityQuery {
+	q.terms = append(q.terms, term)
+	return q
+}
+
+func (q *HacktivityQuery) anyOf(field string, values []string) *HacktivityQuery {
+	if len(values) == 0 {
+		return q
+	} else if len(values) == 1 {
+		return q.add(field + ":" + quoteTerm(values[0]))
+	}
+
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = quoteTerm(value)
+	}
+	return q.add(field + ":(" + strings.Join(quoted, " OR ") + ")")
+}
+
+func (q *HacktivityQuery) between(field string, from, to time.Time) *HacktivityQuery {
+	if from.IsZero() && to.IsZero() {
+		return q
+	}
+
+	bound := func(t time.Time) string {
+		if t.IsZero() {
+			return "*"
+		}
+		return t.UTC().Format("2006-01-02")
+	}
+	return q.add(fmt.Sprintf("%s:[%s TO %s]", field, bound(from), bound(to)))
+}
+
+// luceneSpecial are the characters with a meaning in Lucene query syntax.
+const luceneSpecial = `+-&|!(){}[]^"~*?:\/ `
+
+// quoteTerm returns value as a single term, values with special characters or spaces are quoted.
+func quoteTerm(value string) string {
+	if value != "" && !strings.ContainsAny(value, luceneSpecial) {
+		return value
+	}
+
+	escaped := strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value)
+	return `"` + escaped + `"`
+}
+
+// Hacktivity iterates over hacktivity items matching query, both query and input are optional.
+func (h1 *Hackerone) Hacktivity(query *HacktivityQuery, input *ListInput) iter.Seq2[types.HacktivityItem, error] {
+	if input == nil {
+		input = &ListInput{}
+	}
+
+	uri := "https://api.hackerone.com/v1/hackers/hacktivity"
+	if q := query.String(); q != "" {
+		uri += "?" + url.Values{"queryString": {q}}.Encode()
+	}
+
+	items := Paginate[types.HacktivityItem](h1, &PaginateInput{
+		Uri:       uri,
+		ListInput: *input,
+	})
+
+	return func(yield func(types.HacktivityItem, error) bool) {
+		for item, err := range items {
+			if err != nil {
+				yield(item, fmt.Errorf("Hacktivity: %w", err))
+				return
+			} else if !yield(item, nil) {
+				return
+			}
+		}
+	}
+}
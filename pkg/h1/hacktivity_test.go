@@ -0,0 +1,83 @@
+package h1
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestHacktivityQuery_String(t *testing.T) {
+	tests := []struct {
+		name  string
+		query *HacktivityQuery
+		want  string
+	}{
+		{name: "nil query", query: nil, want: ""},
+		{name: "empty query", query: NewHacktivityQuery(), want: ""},
+		{
+			name:  "single values",
+			query: NewHacktivityQuery().Severity("critical").Disclosed(true).Program("security"),
+			want:  "severity_rating:critical AND disclosed:true AND team:security",
+		},
+		{
+			name:  "any of several values",
+			query: NewHacktivityQuery().Severity("critical", "high"),
+			want:  "severity_rating:(critical OR high)",
+		},
+		{
+			name:  "values with special characters are quoted",
+			query: NewHacktivityQuery().Weakness("Cross-site Scripting (XSS) - Stored", `say "hi"`),
+			want:  `cwe:("Cross-site Scripting (XSS) - Stored" OR "say \"hi\"")`,
+		},
+		{
+			name: "date ranges",
+			query: NewHacktivityQuery().
+				DisclosedBetween(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)).
+				SubmittedBetween(time.Time{}, time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)),
+			want: "disclosed_at:[2024-01-01 TO 2024-12-31] AND submitted_at:[* TO 2023-06-01]",
+		},
+		{
+			name:  "bounty thresholds",
+			query: NewHacktivityQuery().MinBounty(1000).MaxBounty(2500.5),
+			want:  "total_awarded_amount:>=1000 AND total_awarded_amount:<=2500.5",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.query.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHackerone_Hacktivity(t *testing.T) {
+	mockClient := &MockClient{
+		DoResponse: []*http.Response{{
+			StatusCode: 200,
+			Body: io.NopCloser(bytes.NewReader([]byte(`{"data": [
+  {"id": "1", "type": "report", "attributes": {"title": "RCE", "severity_rating": "critical", "disclosed": true, "total_awarded_amount": 10000},
+   "relationships": {"program": {"data": {"id": "13", "type": "program", "attributes": {"handle": "security"}}}}}
+]}`))),
+		}},
+	}
+	h1 := &Hackerone{token: "token", username: "username", client: mockClient}
+
+	var got []string
+	for item, err := range h1.Hacktivity(NewHacktivityQuery().Severity("critical").Disclosed(true), nil) {
+		if err != nil {
+			t.Fatalf("Hacktivity() error = %v", err)
+		}
+		got = append(got, item.Relationships.Program.Data.Attributes.Handle+": "+item.Attributes.Title)
+	}
+
+	if len(got) != 1 || got[0] != "security: RCE" {
+		t.Errorf("Hacktivity() = %v, want [security: RCE]", got)
+	}
+	if q := mockClient.Calls[0].URL.Query().Get("queryString"); q != "severity_rating:critical AND disclosed:true" {
+		t.Errorf("Hacktivity() sent queryString %q", q)
+	}
+}
@@ -0,0 +1,345 @@
+package h1
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"net/url"
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/ryanjarv/h1/pkg/types"
+)
+
+// ListInput is the input parameters shared by all list methods.
+//
+// Cursor is optional, when set the listing resumes from it and it is updated after every page so the caller can
+// inspect or persist its progress.
+// CheckpointPath is optional, when set the cursor is loaded from this file if Cursor is nil, saved to it after
+// every page and removed once the listing completes, so a crashed walk resumes where it left off.
+// Prefetch is optional, when greater than zero up to that many pages are fetched ahead in the background while the
+// current page is being consumed. Prefetched requests still go through the client's rate limiter.
+// PageSize is optional, when set it is sent as page[size], otherwise the server default is used.
+// PageNumber is optional, when set the listing starts at this page instead of the first one. It is ignored when
+// resuming from a cursor.
+// OnPage is optional, it is called after every page has been consumed and can be used to report progress.
+// ErrorPolicy is optional and defaults to ErrorPolicyStop, MaxPageRetries bounds the retries and consecutive skips
+// of the other policies and defaults to DefaultMaxPageRetries.
+// Report is optional, when set it is filled in with the pages, items and errors of the listing as it progresses.
+type ListInput struct {
+	Cursor *Cursor `json:"cursor,omitempty"`
+
+	CheckpointPath string `json:"checkpoint_path,omitempty"`
+
+	Prefetch int `json:"prefetch,omitempty"`
+
+	PageSize int `json:"page_size,omitempty"`
+
+	PageNumber int `json:"page_number,omitempty"`
+
+	OnPage func(PageInfo) `json:"-"`
+
+	ErrorPolicy ErrorPolicy `json:"error_policy,omitempty"`
+
+	MaxPageRetries int `json:"max_page_retries,omitempty"`
+
+	Report *ListReport `json:"-"`
+}
+
+// PaginateInput is the input parameters for Paginate and Pages.
+//
+// Uri must be provided and is the first page of the listing.
+type PaginateInput struct {
+	Uri string `json:"uri"`
+
+	ListInput
+}
+
+// PageInfo is the metadata of a single page of a listing.
+//
+// Number is the 1-based page number, Count the number of items on this page and Seen the number of items on every
+// page consumed so far by this walk. Total is the total number of items in the listing when the API provides it.
+type PageInfo struct {
+	Number int         `json:"number"`
+	Count  int         `json:"count"`
+	Seen   int         `json:"seen"`
+	Total  *int        `json:"total,omitempty"`
+	Links  types.Links `json:"links"`
+}
+
+// Page is a single decoded page of a listing.
+type Page[T any] struct {
+	Items []T `json:"items"`
+
+	PageInfo
+}
+
+// Paginate walks a JSON:API listing starting at input.Uri, decoding the data array of each page into T and
+// following links.next until the last page.
+//
+// Under the default error policy iteration stops after the first error is yielded, see ErrorPolicy for the
+// alternatives. The cursor only advances once every item of a page has been yielded, so resuming after an
+// interrupted page yields that page again.
+func Paginate[T any](h1 *Hackerone, input *PaginateInput) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+
+		for page, err := range Pages[T](h1, input) {
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+
+			for _, item := range page.Items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Pages walks a listing like Paginate but yields whole pages along with their metadata.
+func Pages[T any](h1 *Hackerone, input *PaginateInput) iter.Seq2[*Page[T], error] {
+	return func(yield func(*Page[T], error) bool) {
+		fail := func(err error) {
+			input.Report.addError(err)
+			yield(nil, err)
+		}
+
+		cursor, err := input.cursor()
+		if err != nil {
+			fail(fmt.Errorf("paginate: %w", err))
+			return
+		} else if cursor.done() {
+			return
+		}
+
+		uri := cursor.Next
+		if uri == "" {
+			if uri, err = input.firstPage(); err != nil {
+				fail(fmt.Errorf("paginate: %w", err))
+				return
+			}
+		}
+
+		seen := 0
+		for fetched := range h1.fetchPages(&input.ListInput, uri) {
+			if fetched.err != nil {
+				err := fmt.Errorf("paginate: getting %s: %w", fetched.uri, fetched.err)
+				if input.ErrorPolicy != ErrorPolicySkip || fetched.next == "" {
+					fail(err)
+					return
+				}
+
+				input.Report.addError(err)
+				cursor.Next = fetched.next
+				cursor.Page++
+				continue
+			}
+
+			items := make([]T, 0, len(fetched.data))
+			for i, raw := range fetched.data {
+				var item T
+				if err := json.Unmarshal(raw, &item); err != nil {
+					err := fmt.Errorf("paginate: failed to unmarshal item %d of %s: %w", i, fetched.uri, err)
+					if input.ErrorPolicy != ErrorPolicySkip {
+						fail(err)
+						return
+					}
+
+					input.Report.addError(err)
+					continue
+				}
+				items = append(items, item)
+			}
+
+			seen += len(items)
+			page := &Page[T]{
+				Items: items,
+				PageInfo: PageInfo{
+					Number: pageNumber(fetched.uri, cursor.Page+1),
+					Count:  len(items),
+					Seen:   seen,
+					Total:  fetched.total,
+					Links:  fetched.links,
+				},
+			}
+			if !yield(page, nil) {
+				return
+			}
+
+			input.Report.addPage(len(items))
+			cursor.Next = fetched.next
+			cursor.Page++
+			if err := input.checkpoint(cursor); err != nil {
+				fail(fmt.Errorf("paginate: %w", err))
+				return
+			}
+
+			if input.OnPage != nil {
+				input.OnPage(page.PageInfo)
+			}
+		}
+	}
+}
+
+// firstPage returns input.Uri with the requested page size and number added to its query.
+func (input *PaginateInput) firstPage() (string, error) {
+	if input.PageSize == 0 && input.PageNumber == 0 {
+		return input.Uri, nil
+	}
+
+	u, err := url.Parse(input.Uri)
+	if err != nil {
+		return "", fmt.Errorf("parsing %s: %w", input.Uri, err)
+	}
+
+	query := u.Query()
+	if input.PageSize != 0 {
+		query.Set("page[size]", strconv.Itoa(input.PageSize))
+	}
+	if input.PageNumber != 0 {
+		query.Set("page[number]", strconv.Itoa(input.PageNumber))
+	}
+	u.RawQuery = query.Encode()
+
+	return u.String(), nil
+}
+
+// pageNumber returns the page[number] query parameter of uri, or fallback if it isn't set.
+func pageNumber(uri string, fallback int) int {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return fallback
+	}
+
+	number, err := strconv.Atoi(u.Query().Get("page[number]"))
+	if err != nil {
+		return fallback
+	}
+
+	return number
+}
+
+type fetchedPage struct {
+	uri   string
+	next  string
+	data  []json.RawMessage
+	links types.Links
+	total *int
+	err   error
+}
+
+// fetchPage requests and decodes the envelope of a single page, leaving the items to be decoded by the caller.
+func (h1 *Hackerone) fetchPage(uri string) fetchedPage {
+	body, next, err := h1.send("GET", uri, nil)
+	if err != nil {
+		return fetchedPage{uri: uri, err: err}
+	}
+
+	envelope := struct {
+		Data  []json.RawMessage `json:"data"`
+		Links types.Links       `json:"links"`
+		Meta  struct {
+			TotalCount *int `json:"total_count"`
+		} `json:"meta"`
+	}{}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return fetchedPage{uri: uri, err: fmt.Errorf("failed to unmarshal page: %w", err)}
+	}
+
+	return fetchedPage{
+		uri:   uri,
+		next:  next,
+		data:  envelope.Data,
+		links: envelope.Links,
+		total: envelope.Meta.TotalCount,
+	}
+}
+
+// fetchPages fetches every page starting at uri in order, stopping after the last page or the first failed page
+// the input's error policy can't recover from.
+//
+// Pages are linked through links.next so they can only be requested one after another, with input.Prefetch greater
+// than zero a background goroutine keeps requesting up to that many pages ahead of the consumer. Breaking out of
+// the loop stops the goroutine and waits for any in-flight request so nothing outlives the iterator.
+func (h1 *Hackerone) fetchPages(input *ListInput, uri string) iter.Seq[fetchedPage] {
+	fetchAll := func(yield func(fetchedPage) bool) {
+		uri := uri
+		failures := 0
+		for uri != "" {
+			page := h1.fetchWithPolicy(input, uri, failures)
+			if page.err != nil {
+				failures++
+			} else {
+				failures = 0
+			}
+
+			if !yield(page) {
+				return
+			}
+			uri = page.next
+		}
+	}
+
+	if input.Prefetch <= 0 {
+		return fetchAll
+	}
+
+	return func(yield func(fetchedPage) bool) {
+		pages := make(chan fetchedPage, input.Prefetch-1)
+		done := make(chan struct{})
+
+		var wg sync.WaitGroup
+		defer wg.Wait()
+		defer close(done)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer close(pages)
+
+			fetchAll(func(page fetchedPage) bool {
+				select {
+				case pages <- page:
+					return true
+				case <-done:
+					return false
+				}
+			})
+		}()
+
+		for page := range pages {
+			if !yield(page) {
+				return
+			}
+		}
+	}
+}
+
+func (input *ListInput) cursor() (*Cursor, error) {
+	if input.Cursor != nil {
+		return input.Cursor, nil
+	} else if input.CheckpointPath == "" {
+		return &Cursor{}, nil
+	}
+
+	return LoadCursor(input.CheckpointPath)
+}
+
+func (input *ListInput) checkpoint(cursor *Cursor) error {
+	if input.CheckpointPath == "" {
+		return nil
+	} else if !cursor.done() {
+		return SaveCursor(input.CheckpointPath, cursor)
+	}
+
+	if err := os.Remove(input.CheckpointPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("removing checkpoint %s: %w", input.CheckpointPath, err)
+	}
+
+	return nil
+}
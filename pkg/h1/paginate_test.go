@@ -0,0 +1,224 @@
+package h1
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/ryanjarv/h1/pkg/types"
+)
+
+func TestPaginate(t *testing.T) {
+	type item struct {
+		Id string `json:"id"`
+	}
+
+	tests := []struct {
+		name                 string
+		responses            []*http.Response
+		prefetch             int
+		stopAfter            int
+		want                 []item
+		wantErr              bool
+		wantTimesDoCalled    int
+		wantMaxTimesDoCalled int
+	}{
+		{
+			name: "follows next links",
+			responses: []*http.Response{
+				{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(`{"data": [{"id": "1"}, {"id": "2"}], "links": {"next": "test"}}`)))},
+				{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(`{"data": [{"id": "3"}]}`)))},
+			},
+			want:              []item{{Id: "1"}, {Id: "2"}, {Id: "3"}},
+			wantTimesDoCalled: 2,
+		},
+		{
+			name: "breaking early does not fetch the next page",
+			responses: []*http.Response{
+				{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(`{"data": [{"id": "1"}, {"id": "2"}], "links": {"next": "test"}}`)))},
+			},
+			stopAfter:         1,
+			want:              []item{{Id: "1"}},
+			wantTimesDoCalled: 1,
+		},
+		{
+			name: "error after a failed page",
+			responses: []*http.Response{
+				{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(`{"data": [{"id": "1"}], "links": {"next": "test"}}`)))},
+				{StatusCode: 404, Status: "404 Not Found", Body: io.NopCloser(bytes.NewReader([]byte(`{}`)))},
+			},
+			want:              []item{{Id: "1"}},
+			wantErr:           true,
+			wantTimesDoCalled: 2,
+		},
+		{
+			name:     "prefetching preserves order",
+			prefetch: 2,
+			responses: []*http.Response{
+				{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(`{"data": [{"id": "1"}], "links": {"next": "test"}}`)))},
+				{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(`{"data": [{"id": "2"}], "links": {"next": "test"}}`)))},
+				{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(`{"data": [{"id": "3"}]}`)))},
+			},
+			want:              []item{{Id: "1"}, {Id: "2"}, {Id: "3"}},
+			wantTimesDoCalled: 3,
+		},
+		{
+			name:     "prefetching stops when the consumer breaks",
+			prefetch: 1,
+			responses: []*http.Response{
+				{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(`{"data": [{"id": "1"}], "links": {"next": "test"}}`)))},
+				{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(`{"data": [{"id": "2"}], "links": {"next": "test"}}`)))},
+				{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(`{"data": [{"id": "3"}], "links": {"next": "test"}}`)))},
+				{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(`{"data": [{"id": "4"}]}`)))},
+			},
+			stopAfter:            1,
+			want:                 []item{{Id: "1"}},
+			wantMaxTimesDoCalled: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockClient{DoResponse: tt.responses}
+			h1 := &Hackerone{token: "token", username: "username", client: mockClient}
+
+			var got []item
+			var gotErr error
+			for i, err := range Paginate[item](h1, &PaginateInput{
+				Uri:       "https://example.com",
+				ListInput: ListInput{Prefetch: tt.prefetch},
+			}) {
+				if err != nil {
+					gotErr = err
+					continue
+				}
+				got = append(got, i)
+				if tt.stopAfter != 0 && len(got) == tt.stopAfter {
+					break
+				}
+			}
+
+			if (gotErr != nil) != tt.wantErr {
+				t.Errorf("Paginate() error = %v, wantErr %v", gotErr, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Paginate() mismatch (-want +got):\n%s", diff)
+			}
+			if tt.wantTimesDoCalled != 0 && mockClient.CallCount != tt.wantTimesDoCalled {
+				t.Errorf("Do() called %d times, want %d", mockClient.CallCount, tt.wantTimesDoCalled)
+			}
+			if tt.wantMaxTimesDoCalled != 0 && mockClient.CallCount > tt.wantMaxTimesDoCalled {
+				t.Errorf("Do() called %d times, want at most %d", mockClient.CallCount, tt.wantMaxTimesDoCalled)
+			}
+		})
+	}
+}
+
+func TestPaginate_Checkpoint(t *testing.T) {
+	type item struct {
+		Id string `json:"id"`
+	}
+
+	path := filepath.Join(t.TempDir(), "cursor.json")
+
+	// The first walk dies part way through the second page.
+	h1 := &Hackerone{token: "token", username: "username", client: &MockClient{
+		DoResponse: []*http.Response{
+			{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(`{"data": [{"id": "1"}], "links": {"next": "https://example.com/2"}}`)))},
+			{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(`{"data": [{"id": "2"}], "links": {"next": "https://example.com/3"}}`)))},
+		},
+	}}
+	input := &PaginateInput{Uri: "https://example.com/1", ListInput: ListInput{CheckpointPath: path}}
+	for i, err := range Paginate[item](h1, input) {
+		if err != nil {
+			t.Fatalf("Paginate() error = %v", err)
+		}
+		if i.Id == "2" {
+			break
+		}
+	}
+
+	cursor, err := LoadCursor(path)
+	if err != nil {
+		t.Fatalf("LoadCursor() error = %v", err)
+	}
+	if diff := cmp.Diff(&Cursor{Next: "https://example.com/2", Page: 1}, cursor); diff != "" {
+		t.Errorf("LoadCursor() mismatch (-want +got):\n%s", diff)
+	}
+
+	// The second walk resumes from the interrupted page and removes the checkpoint once done.
+	mockClient := &MockClient{
+		DoResponse: []*http.Response{
+			{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(`{"data": [{"id": "2"}], "links": {"next": "https://example.com/3"}}`)))},
+			{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(`{"data": [{"id": "3"}]}`)))},
+		},
+	}
+	h1.client = mockClient
+
+	var got []item
+	for i, err := range Paginate[item](h1, input) {
+		if err != nil {
+			t.Fatalf("Paginate() error = %v", err)
+		}
+		got = append(got, i)
+	}
+
+	if diff := cmp.Diff([]item{{Id: "2"}, {Id: "3"}}, got); diff != "" {
+		t.Errorf("Paginate() mismatch (-want +got):\n%s", diff)
+	}
+	if uri := mockClient.Calls[0].URL.String(); uri != "https://example.com/2" {
+		t.Errorf("Paginate() resumed from %s, want https://example.com/2", uri)
+	}
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("checkpoint %s still exists after the listing completed", path)
+	}
+}
+
+func TestPages(t *testing.T) {
+	type item struct {
+		Id string `json:"id"`
+	}
+
+	mockClient := &MockClient{
+		DoResponse: []*http.Response{
+			{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(`{"data": [{"id": "1"}, {"id": "2"}], "links": {"next": "https://example.com/?page%5Bnumber%5D=2&page%5Bsize%5D=2"}, "meta": {"total_count": 3}}`)))},
+			{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(`{"data": [{"id": "3"}], "meta": {"total_count": 3}}`)))},
+		},
+	}
+	h1 := &Hackerone{token: "token", username: "username", client: mockClient}
+
+	var progress []PageInfo
+	var got []PageInfo
+	for page, err := range Pages[item](h1, &PaginateInput{
+		Uri: "https://example.com/",
+		ListInput: ListInput{
+			PageSize: 2,
+			OnPage:   func(info PageInfo) { progress = append(progress, info) },
+		},
+	}) {
+		if err != nil {
+			t.Fatalf("Pages() error = %v", err)
+		}
+		got = append(got, page.PageInfo)
+	}
+
+	total := 3
+	want := []PageInfo{
+		{Number: 1, Count: 2, Seen: 2, Total: &total, Links: types.Links{Next: "https://example.com/?page%5Bnumber%5D=2&page%5Bsize%5D=2"}},
+		{Number: 2, Count: 1, Seen: 3, Total: &total},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Pages() mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(want, progress); diff != "" {
+		t.Errorf("OnPage() mismatch (-want +got):\n%s", diff)
+	}
+	if size := mockClient.Calls[0].URL.Query().Get("page[size]"); size != "2" {
+		t.Errorf("Pages() sent page[size]=%q, want 2", size)
+	}
+}
@@ -0,0 +1,86 @@
+package h1
+
+import (
+	"encoding/json"
+	"fmt"
+	"iter"
+
+	"github.com/ryanjarv/h1/pkg/types"
+)
+
+// Balance returns the authenticated user's current balance.
+func (h1 *Hackerone) Balance() (*types.Balance, error) {
+	resp, _, err := h1.send("GET", "https://api.hackerone.com/v1/hackers/payments/balance", nil)
+	if err != nil {
+		return nil, fmt.Errorf("Balance: getting balance: %w", err)
+	}
+
+	balance := struct {
+		Data types.Balance `json:"data"`
+	}{}
+	if err := json.Unmarshal(resp, &balance); err != nil {
+		return nil, fmt.Errorf("Balance: failed to unmarshal balance: %w", err)
+	}
+
+	if balance.Data.Currency == "" {
+		balance.Data.Currency = types.DefaultCurrency
+	}
+
+	return &balance.Data, nil
+}
+
+// Earnings iterates over every earning credited to the authenticated user, input is optional.
+func (h1 *Hackerone) Earnings(input *ListInput) iter.Seq2[types.Earning, error] {
+	if input == nil {
+		input = &ListInput{}
+	}
+
+	earnings := Paginate[types.Earning](h1, &PaginateInput{
+		Uri:       "https://api.hackerone.com/v1/hackers/payments/earnings",
+		ListInput: *input,
+	})
+
+	return func(yield func(types.Earning, error) bool) {
+		for earning, err := range earnings {
+			if err != nil {
+				yield(earning, fmt.Errorf("Earnings: %w", err))
+				return
+			}
+
+			if earning.Attributes.Currency == "" {
+				earning.Attributes.Currency = types.DefaultCurrency
+			}
+			if !yield(earning, nil) {
+				return
+			}
+		}
+	}
+}
+
+// Payouts iterates over every payout made to the authenticated user, input is optional.
+func (h1 *Hackerone) Payouts(input *ListInput) iter.Seq2[types.Payout, error] {
+	if input == nil {
+		input = &ListInput{}
+	}
+
+	payouts := Paginate[types.Payout](h1, &PaginateInput{
+		Uri:       "https://api.hackerone.com/v1/hackers/payments/payouts",
+		ListInput: *input,
+	})
+
+	return func(yield func(types.Payout, error) bool) {
+		for payout, err := range payouts {
+			if err != nil {
+				yield(payout, fmt.Errorf("Payouts: %w", err))
+				return
+			}
+
+			if payout.Currency == "" {
+				payout.Currency = types.DefaultCurrency
+			}
+			if !yield(payout, nil) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package h1
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/ryanjarv/h1/pkg/types"
+)
+
+func TestHackerone_Balance(t *testing.T) {
+	h1 := &Hackerone{token: "token", username: "username", client: &MockClient{
+		DoResponse: []*http.Response{{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(`{"data": {"balance": 1250.5}}`)))}},
+	}}
+
+	got, err := h1.Balance()
+	if err != nil {
+		t.Fatalf("Balance() error = %v", err)
+	}
+	if diff := cmp.Diff(&types.Balance{Balance: 1250.5, Currency: "USD"}, got); diff != "" {
+		t.Errorf("Balance() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestHackerone_Earnings(t *testing.T) {
+	h1 := &Hackerone{token: "token", username: "username", client: &MockClient{
+		DoResponse: []*http.Response{
+			{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(`{"data": [
+  {"id": "1", "type": "earning-bounty-earned", "attributes": {"amount": 500},
+   "relationships": {"program": {"data": {"id": "13", "type": "program", "attributes": {"handle": "security"}}}}}
+], "links": {"next": "test"}}`)))},
+			{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(`{"data": [
+  {"id": "2", "type": "earning-retest-completed", "attributes": {"amount": 50, "currency": "EUR"}}
+]}`)))},
+		},
+	}}
+
+	type earning struct {
+		Id, Program, Currency string
+		Amount                float64
+	}
+	var got []earning
+	for e, err := range h1.Earnings(nil) {
+		if err != nil {
+			t.Fatalf("Earnings() error = %v", err)
+		}
+		got = append(got, earning{
+			Id:       e.Id,
+			Program:  e.Relationships.Program.Data.Attributes.Handle,
+			Currency: e.Attributes.Currency,
+			Amount:   e.Attributes.Amount,
+		})
+	}
+
+	want := []earning{
+		{Id: "1", Program: "security", Currency: "USD", Amount: 500},
+		{Id: "2", Currency: "EUR", Amount: 50},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Earnings() mismatch (-want +got):\n%s", diff)
+	}
+}
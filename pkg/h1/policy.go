@@ -0,0 +1,158 @@
+package h1
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ErrorPolicy controls what a listing does when a page or an item fails.
+type ErrorPolicy int
+
+const (
+	// ErrorPolicyStop yields the first error and ends the listing. This is the default.
+	ErrorPolicyStop ErrorPolicy = iota
+
+	// ErrorPolicySkip records errors in the ListReport instead of yielding them. Items that fail to decode are
+	// dropped and failed pages are skipped by advancing page[number], giving up after MaxPageRetries consecutive
+	// failures or on an authentication error since every following page would fail the same way.
+	ErrorPolicySkip
+
+	// ErrorPolicyRetry retries a failed page up to MaxPageRetries times before yielding the error and ending the
+	// listing. Client errors other than 429 are not retried.
+	ErrorPolicyRetry
+)
+
+// DefaultMaxPageRetries is used when ListInput.MaxPageRetries is not set.
+const DefaultMaxPageRetries = 3
+
+// pageRetryBackoff is multiplied by the attempt number to get the delay before retrying a failed page.
+var pageRetryBackoff = time.Second
+
+// ListReport is the aggregated outcome of a listing.
+//
+// Pages and Items count what was successfully yielded, Errors holds every error the listing encountered, including
+// the ones skipped under ErrorPolicySkip.
+type ListReport struct {
+	mu sync.Mutex
+
+	Pages  int     `json:"pages"`
+	Items  int     `json:"items"`
+	Errors []error `json:"-"`
+}
+
+// Err returns all errors encountered by the listing joined together, or nil if there were none.
+func (r *ListReport) Err() error {
+	if r == nil {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return errors.Join(r.Errors...)
+}
+
+// Log writes a one line summary of the listing, followed by every error it encountered.
+func (r *ListReport) Log(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	log.Printf("%s: listed %d items over %d pages with %d errors", name, r.Items, r.Pages, len(r.Errors))
+	for _, err := range r.Errors {
+		log.Printf("%s: %s", name, err)
+	}
+}
+
+func (r *ListReport) addError(err error) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.Errors = append(r.Errors, err)
+}
+
+func (r *ListReport) addPage(items int) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.Pages++
+	r.Items += items
+}
+
+// fetchWithPolicy fetches a single page, applying the input's error policy to failed requests and responses that
+// can't be decoded.
+//
+// Under ErrorPolicySkip a failed page is returned with next pointing at the following page so the listing can carry
+// on, failures is the number of consecutive failed pages so far and is used to give up on a listing that never
+// recovers.
+func (h1 *Hackerone) fetchWithPolicy(input *ListInput, uri string, failures int) fetchedPage {
+	maxRetries := input.MaxPageRetries
+	if maxRetries == 0 {
+		maxRetries = DefaultMaxPageRetries
+	}
+
+	page := h1.fetchPage(uri)
+	for attempt := 1; page.err != nil && input.ErrorPolicy == ErrorPolicyRetry && attempt <= maxRetries; attempt++ {
+		if !retryable(page.err) {
+			break
+		}
+
+		backoff := time.Duration(attempt) * pageRetryBackoff
+		log.Printf("page failed (attempt %d/%d): %s, retrying in %v", attempt, maxRetries, page.err, backoff)
+		time.Sleep(backoff)
+
+		page = h1.fetchPage(uri)
+	}
+
+	if page.err == nil || input.ErrorPolicy != ErrorPolicySkip {
+		return page
+	}
+
+	if code := statusCode(page.err); code == 401 || code == 403 || failures+1 >= maxRetries {
+		return page
+	}
+
+	next, err := skipPage(uri)
+	if err != nil {
+		page.err = errors.Join(page.err, err)
+		return page
+	}
+	page.next = next
+
+	return page
+}
+
+// retryable reports whether a failed page may succeed if it is fetched again.
+func retryable(err error) bool {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.Temporary()
+	}
+	return true
+}
+
+// skipPage returns uri with its page[number] incremented, a uri without a page number is the first page.
+func skipPage(uri string) (string, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return "", fmt.Errorf("skipping page %s: %w", uri, err)
+	}
+
+	query := u.Query()
+	query.Set("page[number]", strconv.Itoa(pageNumber(uri, 1)+1))
+	u.RawQuery = query.Encode()
+
+	return u.String(), nil
+}
@@ -0,0 +1,132 @@
+package h1
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestListPrograms_ErrorPolicy(t *testing.T) {
+	pageRetryBackoff = time.Millisecond
+	defer func() { pageRetryBackoff = time.Second }()
+
+	tests := []struct {
+		name              string
+		policy            ErrorPolicy
+		responses         []*http.Response
+		want              []Program
+		wantErr           bool
+		wantReportErrs    int
+		wantReportItems   int
+		wantTimesDoCalled int
+	}{
+		{
+			name:   "stop yields the first error",
+			policy: ErrorPolicyStop,
+			responses: []*http.Response{
+				{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(`{"data": [{"id": "1"}], "links": {"next": "https://example.com/?page%5Bnumber%5D=2"}}`)))},
+				{StatusCode: 500, Body: io.NopCloser(bytes.NewReader([]byte(`error`)))},
+			},
+			want:              []Program{{Id: "1"}},
+			wantErr:           true,
+			wantReportErrs:    1,
+			wantReportItems:   1,
+			wantTimesDoCalled: 2,
+		},
+		{
+			name:   "skip drops items that fail to decode",
+			policy: ErrorPolicySkip,
+			responses: []*http.Response{
+				{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(`{"data": [{"id": "1"}, {"id": 2}, {"id": "3"}]}`)))},
+			},
+			want:              []Program{{Id: "1"}, {Id: "3"}},
+			wantReportErrs:    1,
+			wantReportItems:   2,
+			wantTimesDoCalled: 1,
+		},
+		{
+			name:   "skip moves on to the next page number",
+			policy: ErrorPolicySkip,
+			responses: []*http.Response{
+				{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(`{"data": [{"id": "1"}], "links": {"next": "https://example.com/?page%5Bnumber%5D=2"}}`)))},
+				{StatusCode: 500, Body: io.NopCloser(bytes.NewReader([]byte(`error`)))},
+				{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(`{"data": [{"id": "3"}]}`)))},
+			},
+			want:              []Program{{Id: "1"}, {Id: "3"}},
+			wantReportErrs:    1,
+			wantReportItems:   2,
+			wantTimesDoCalled: 3,
+		},
+		{
+			name:   "skip gives up on authentication errors",
+			policy: ErrorPolicySkip,
+			responses: []*http.Response{
+				{StatusCode: 401, Body: io.NopCloser(bytes.NewReader([]byte(`error`)))},
+			},
+			wantErr:           true,
+			wantReportErrs:    1,
+			wantTimesDoCalled: 1,
+		},
+		{
+			name:   "retry refetches a failed page",
+			policy: ErrorPolicyRetry,
+			responses: []*http.Response{
+				{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(`{"data": [{"id": "1"}], "links": {"next": "https://example.com/?page%5Bnumber%5D=2"}}`)))},
+				{StatusCode: 503, Body: io.NopCloser(bytes.NewReader([]byte(`error`)))},
+				{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(`{"data": [{"id": "2"}]}`)))},
+			},
+			want:              []Program{{Id: "1"}, {Id: "2"}},
+			wantReportItems:   2,
+			wantTimesDoCalled: 3,
+		},
+		{
+			name:   "retry does not refetch client errors",
+			policy: ErrorPolicyRetry,
+			responses: []*http.Response{
+				{StatusCode: 404, Body: io.NopCloser(bytes.NewReader([]byte(`error`)))},
+			},
+			wantErr:           true,
+			wantReportErrs:    1,
+			wantTimesDoCalled: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockClient{DoResponse: tt.responses}
+			h1 := &Hackerone{token: "token", username: "username", client: mockClient}
+
+			report := &ListReport{}
+			var got []Program
+			var gotErr error
+			for p, err := range h1.ListPrograms(&ListInput{ErrorPolicy: tt.policy, Report: report}) {
+				if err != nil {
+					gotErr = err
+					continue
+				}
+				got = append(got, *p)
+			}
+
+			if (gotErr != nil) != tt.wantErr {
+				t.Errorf("ListPrograms() error = %v, wantErr %v", gotErr, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got, cmpopts.IgnoreFields(Program{}, "Hackerone"), cmpopts.IgnoreUnexported(Program{})); diff != "" {
+				t.Errorf("ListPrograms() mismatch (-want +got):\n%s", diff)
+			}
+			if len(report.Errors) != tt.wantReportErrs {
+				t.Errorf("ListReport.Errors = %v, want %d errors", report.Errors, tt.wantReportErrs)
+			}
+			if report.Items != tt.wantReportItems {
+				t.Errorf("ListReport.Items = %d, want %d", report.Items, tt.wantReportItems)
+			}
+			if mockClient.CallCount != tt.wantTimesDoCalled {
+				t.Errorf("Do() called %d times, want %d", mockClient.CallCount, tt.wantTimesDoCalled)
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"iter"
 	"log"
 	"net"
 	"net/http"
@@ -19,48 +20,56 @@ import (
 
 const MaxRetries = 3
 
+// Programs iterates over all programs, skipping any page or program that fails. Skipped errors are logged once the
+// listing ends, use ListPrograms to handle them directly.
 func (h1 *Hackerone) Programs(yield func(Program) bool) {
-	h1.ProgramsWithErrs(func(p *Program, err error) bool {
-		if err != nil {
-			log.Printf("error getting programs: %s", err)
-			return true
-		} else if p == nil {
-			panic(fmt.Errorf("got nil program with no error"))
+	report := &ListReport{}
+	defer func() {
+		if report.Err() != nil {
+			report.Log("programs")
 		}
+	}()
 
-		return yield(*p)
-	})
+	for p := range h1.ListPrograms(&ListInput{ErrorPolicy: ErrorPolicySkip, Report: report}) {
+		if p == nil {
+			continue
+		} else if !yield(*p) {
+			return
+		}
+	}
 }
 
+// ProgramsWithErrs iterates over all programs, stopping at the first error.
 func (h1 *Hackerone) ProgramsWithErrs(yield func(*Program, error) bool) {
-	uri := fmt.Sprintf("https://api.hackerone.com/v1/hackers/programs")
+	h1.ListPrograms(nil)(yield)
+}
 
-	for uri != "" {
-		var err error
-		var resp []byte
-		resp, uri, err = h1.send("GET", uri, nil)
-		if err != nil {
-			if !yield(nil, fmt.Errorf("programs: getting programs: %w", err)) {
-				return
-			}
-		}
+// ListPrograms iterates over all programs, input is optional and controls where the listing resumes from and how
+// errors are handled.
+func (h1 *Hackerone) ListPrograms(input *ListInput) iter.Seq2[*Program, error] {
+	if input == nil {
+		input = &ListInput{}
+	}
 
-		data := struct {
-			Data []types.ProgramDetail
-		}{}
-		if err := json.Unmarshal(resp, &data); err != nil {
-			if !yield(nil, fmt.Errorf("programs: failed to unmarshal programs: %w", err)) {
+	programs := Paginate[types.ProgramDetail](h1, &PaginateInput{
+		Uri:       "https://api.hackerone.com/v1/hackers/programs",
+		ListInput: *input,
+	})
+
+	return func(yield func(*Program, error) bool) {
+		for p, err := range programs {
+			if err != nil {
+				yield(nil, fmt.Errorf("programs: %w", err))
 				return
 			}
-		}
 
-		for _, p := range data.Data {
 			if !yield(&Program{
 				Hackerone:         h1,
 				Id:                p.Id,
 				Type:              p.Type,
 				Handle:            p.Attributes.Handle,
 				ProgramAttributes: p.Attributes,
+				cache:             &programCache{},
 			}, nil) {
 				return
 			}
@@ -72,6 +81,7 @@ func (h1 *Hackerone) Program(handle string) *Program {
 	return &Program{
 		Hackerone: h1,
 		Handle:    handle,
+		cache:     &programCache{},
 	}
 }
 
@@ -85,11 +95,80 @@ type Program struct {
 	Type string `json:"type,omitempty"`
 
 	types.ProgramAttributes
+
+	cache *programCache
 }
 
 func (h1 *Program) GetId() string { return h1.Handle }
 
+// UnmarshalJSON decodes the program's own fields alongside its attributes, it is needed because the embedded
+// ProgramAttributes would otherwise take over decoding of the whole object.
+func (h1 *Program) UnmarshalJSON(data []byte) error {
+	if err := h1.ProgramAttributes.UnmarshalJSON(data); err != nil {
+		return err
+	}
+
+	fields := struct {
+		Handle string `json:"handle"`
+		Id     string `json:"id"`
+		Type   string `json:"type"`
+	}{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	h1.Handle, h1.Id, h1.Type = fields.Handle, fields.Id, fields.Type
+
+	delete(h1.Extra, "id")
+	delete(h1.Extra, "type")
+	if len(h1.Extra) == 0 {
+		h1.Extra = nil
+	}
+
+	return nil
+}
+
+// MarshalJSON encodes the program's own fields alongside its attributes, see UnmarshalJSON.
+func (h1 Program) MarshalJSON() ([]byte, error) {
+	data, err := h1.ProgramAttributes.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	all := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+
+	fields := map[string]string{"handle": h1.Handle, "id": h1.Id, "type": h1.Type}
+	for key, value := range fields {
+		if value == "" && key != "handle" {
+			continue
+		}
+		if all[key], err = json.Marshal(value); err != nil {
+			return nil, err
+		}
+	}
+
+	return json.Marshal(all)
+}
+
 func (h1 *Program) GetDetail() (*types.ProgramDetail, error) {
+	return h1.GetDetailWithInput(nil)
+}
+
+// GetDetailInput is the input parameters for GetDetailWithInput.
+//
+// AllScopes is optional, the structured scopes embedded in the program detail are capped so when set they are
+// replaced with every scope listed by StructuredScopes.
+type GetDetailInput struct {
+	AllScopes bool `json:"all_scopes,omitempty"`
+}
+
+func (h1 *Program) GetDetailWithInput(input *GetDetailInput) (*types.ProgramDetail, error) {
+	if input == nil {
+		input = &GetDetailInput{}
+	}
+
 	uri := fmt.Sprintf("https://api.hackerone.com/v1/hackers/programs/%s", h1.Handle)
 
 	resp, uri, err := h1.send("GET", uri, nil)
@@ -105,31 +184,71 @@ func (h1 *Program) GetDetail() (*types.ProgramDetail, error) {
 		return nil, fmt.Errorf("GetDetail: failed to unmarshal program: %w", err)
 	}
 
+	if input.AllScopes {
+		var scopes []types.ScopeData
+		for scope, err := range h1.StructuredScopes() {
+			if err != nil {
+				return nil, fmt.Errorf("GetDetail: %w", err)
+			}
+			scopes = append(scopes, scope)
+		}
+		program.Relationships.StructuredScopes.Data = scopes
+	}
+
 	return &program, nil
 }
 
-func (h1 *Program) GetWeaknesses() (*types.Weaknesses, error) {
-	uri := fmt.Sprintf("https://api.hackerone.com/v1/hackers/programs/%s/weaknesses", h1.Handle)
+// StructuredScopes iterates over every page of the program's structured scopes.
+func (h1 *Program) StructuredScopes() iter.Seq2[types.ScopeData, error] {
+	return h1.ListStructuredScopes(nil)
+}
 
-	var resp []byte
-	var err error
+// ListStructuredScopes iterates over every page of the program's structured scopes, input is optional and controls
+// the page size, where the listing resumes from and how errors are handled.
+func (h1 *Program) ListStructuredScopes(input *ListInput) iter.Seq2[types.ScopeData, error] {
+	if input == nil {
+		input = &ListInput{}
+	}
 
-	resp, uri, err = h1.send("GET", uri, nil)
-	if err != nil {
-		return nil, fmt.Errorf("GetWeaknesses: getting weaknesses: %w", err)
-	} else if uri != "" {
-		return nil, fmt.Errorf("GetWeaknesses: unexpected pagination for weaknesses: %s", uri)
+	return Paginate[types.ScopeData](h1.Hackerone, &PaginateInput{
+		Uri:       fmt.Sprintf("https://api.hackerone.com/v1/hackers/programs/%s/structured_scopes", h1.Handle),
+		ListInput: *input,
+	})
+}
+
+// ListWeaknesses iterates over every page of the program's weaknesses without caching them, input is optional and
+// controls the page size, where the listing resumes from and how errors are handled.
+func (h1 *Program) ListWeaknesses(input *ListInput) iter.Seq2[types.Weakness, error] {
+	if input == nil {
+		input = &ListInput{}
 	}
 
+	return Paginate[types.Weakness](h1.Hackerone, &PaginateInput{
+		Uri:       fmt.Sprintf("https://api.hackerone.com/v1/hackers/programs/%s/weaknesses", h1.Handle),
+		ListInput: *input,
+	})
+}
+
+// GetWeaknesses returns all of the program's weaknesses, aggregated across every page.
+func (h1 *Program) GetWeaknesses() (*types.Weaknesses, error) {
 	weaknesses := types.Weaknesses{}
-	err = json.Unmarshal(resp, &weaknesses)
-	if err != nil {
-		return nil, fmt.Errorf("GetWeaknesses: failed to unmarshal weaknesses: %w", err)
+	for weakness, err := range h1.ListWeaknesses(nil) {
+		if err != nil {
+			return nil, fmt.Errorf("GetWeaknesses: %w", err)
+		}
+		weaknesses.Data = append(weaknesses.Data, weakness)
 	}
 
 	return &weaknesses, nil
 }
 
+// Send makes an authenticated request to uri through the client's rate limiter, for packages built on top of this
+// one such as customer. body is sent as JSON when it is not nil, the response body is returned along with the uri of
+// the next page if the response is paginated.
+func (h1 *Hackerone) Send(method string, uri string, body io.Reader) ([]byte, string, error) {
+	return h1.send(method, uri, body)
+}
+
 func (h1 *Hackerone) send(method string, uri string, body io.Reader) ([]byte, string, error) {
 	var all []byte
 	var err error
@@ -139,52 +258,87 @@ func (h1 *Hackerone) send(method string, uri string, body io.Reader) ([]byte, st
 		}
 	}
 
+	contentType := ""
+	if body != nil {
+		contentType = "application/json"
+	}
+
+	return h1.sendWith(method, uri, func() (io.Reader, string, error) {
+		return bytes.NewReader(all), contentType, nil
+	})
+}
+
+// bodyFunc returns a fresh request body along with its content type, it is called once per attempt so bodies that
+// can only be read once are recreated when a request is retried.
+type bodyFunc func() (io.Reader, string, error)
+
+func (h1 *Hackerone) sendWith(method string, uri string, newBody bodyFunc) ([]byte, string, error) {
+	var lastErr error
 	for retries := 0; retries < MaxRetries; retries++ {
-		respBody, next, err := h1.sendOnce(method, uri, bytes.NewReader(all))
-		// Check for specific error types
+		body, contentType, err := newBody()
+		if err != nil {
+			return nil, "", fmt.Errorf("send: failed to create request body: %w", err)
+		}
+
+		respBody, next, err := h1.sendOnce(method, uri, contentType, body)
+		// Check for specific error types, only GETs are retried since the server may have already acted on other
+		// requests before the connection was reset.
 		var opErr *net.OpError
 		var sysErr syscall.Errno
-		if errors.As(err, &opErr) {
+		if method == "GET" && errors.As(err, &opErr) {
 			if errors.As(opErr.Err, &sysErr) && errors.Is(sysErr, syscall.ECONNRESET) {
 				// Handle the connection reset specifically with exponential backoff
 				backoff := time.Duration(retries+1) * 100 * time.Millisecond
 				log.Printf("connection reset (attempt %d/%d): %s, retrying in %v", retries+1, MaxRetries, err, backoff)
 				time.Sleep(backoff)
+				lastErr = err
 				continue
 			}
 		}
 		return respBody, next, err
 	}
 
-	return nil, "", fmt.Errorf("failed to send request after %d retries", MaxRetries)
+	return nil, "", fmt.Errorf("failed to send request after %d retries: %w", MaxRetries, lastErr)
 }
 
-func (h1 *Hackerone) sendOnce(method string, uri string, body io.Reader) ([]byte, string, error) {
+func (h1 *Hackerone) sendOnce(method string, uri string, contentType string, body io.Reader) ([]byte, string, error) {
 	req, err := http.NewRequest(method, uri, body)
 	if err != nil {
+		// Client.Do closes the body, close it here too so writers on the other end of a pipe don't block forever.
+		if closer, ok := body.(io.Closer); ok {
+			closer.Close()
+		}
 		return nil, "", fmt.Errorf("failed to create send: %w", err)
 	}
 
 	req.Header = map[string][]string{
 		"Accept": {"application/json"},
 	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
 
 	req.SetBasicAuth(h1.username, h1.token)
+	h1.limiter.Wait()
 	resp, err := h1.client.Do(req)
+	if req.Body != nil {
+		// Streamed bodies are written from another goroutine, closing makes sure it never blocks on an abandoned request.
+		req.Body.Close()
+	}
 	if err != nil {
 		return nil, "", fmt.Errorf("failed to send request: %w", err)
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != 200 {
-		return nil, "", fmt.Errorf("api call failed: %s returned %s", uri, resp.Status)
-	}
-
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return nil, "", fmt.Errorf("failed to read response body: %w", err)
 	}
 
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, "", &StatusError{Uri: uri, StatusCode: resp.StatusCode, Status: resp.Status, Body: respBody}
+	}
+
 	next, err := h1.nextPage(respBody)
 	if err != nil {
 		return nil, "", fmt.Errorf("failed to get next page: %w", err)
@@ -193,6 +347,10 @@ func (h1 *Hackerone) sendOnce(method string, uri string, body io.Reader) ([]byte
 }
 
 func (h1 *Hackerone) nextPage(body []byte) (string, error) {
+	if len(body) == 0 {
+		return "", nil
+	}
+
 	type Resp struct {
 		Links struct {
 			Next string `json:"next"`
@@ -0,0 +1,147 @@
+package h1
+
+import (
+	"fmt"
+	"iter"
+	"sync"
+	"time"
+
+	"github.com/ryanjarv/h1/pkg/types"
+)
+
+// lazy memoizes a value that is loaded on first use. Concurrent callers wait for a single load, errors are not
+// cached so a failed load is retried by the next caller.
+type lazy[T any] struct {
+	mu       sync.Mutex
+	value    T
+	loaded   bool
+	loadedAt time.Time
+}
+
+func (l *lazy[T]) get(load func() (T, error)) (T, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.loaded {
+		return l.value, nil
+	}
+
+	value, err := load()
+	if err != nil {
+		return value, err
+	}
+	l.value, l.loaded, l.loadedAt = value, true, time.Now()
+
+	return value, nil
+}
+
+func (l *lazy[T]) reset() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var zero T
+	l.value, l.loaded, l.loadedAt = zero, false, time.Time{}
+}
+
+func (l *lazy[T]) at() (time.Time, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.loadedAt, l.loaded
+}
+
+// programCache holds the lazily loaded data of a Program, it is shared by copies of the same Program.
+type programCache struct {
+	detail     lazy[*types.ProgramDetail]
+	scopes     lazy[[]types.ScopeData]
+	weaknesses lazy[[]types.Weakness]
+}
+
+// programCacheMu guards creating the cache of programs that were built without one. It is package level rather than
+// part of Program so programs can still be copied.
+var programCacheMu sync.Mutex
+
+// cached returns the program's cache. Programs built as struct literals or unmarshaled rather than through
+// Hackerone.Program or a listing get theirs on first use.
+func (h1 *Program) cached() *programCache {
+	programCacheMu.Lock()
+	defer programCacheMu.Unlock()
+
+	if h1.cache == nil {
+		h1.cache = &programCache{}
+	}
+	return h1.cache
+}
+
+// Detail returns the program detail, fetching it with GetDetail on first use. The detail is shared by every caller
+// and must not be modified.
+func (h1 *Program) Detail() (*types.ProgramDetail, error) {
+	detail, err := h1.cached().detail.get(h1.GetDetail)
+	if err != nil {
+		return nil, fmt.Errorf("Detail: %w", err)
+	}
+	return detail, nil
+}
+
+// Scopes iterates over every structured scope of the program, they are all fetched on first use and served from
+// memory afterwards.
+func (h1 *Program) Scopes() iter.Seq2[types.ScopeData, error] {
+	return cachedSeq(&h1.cached().scopes, h1.StructuredScopes, "Scopes")
+}
+
+// Weaknesses iterates over every weakness of the program, they are all fetched on first use and served from memory
+// afterwards.
+func (h1 *Program) Weaknesses() iter.Seq2[types.Weakness, error] {
+	list := func() iter.Seq2[types.Weakness, error] { return h1.ListWeaknesses(nil) }
+	return cachedSeq(&h1.cached().weaknesses, list, "Weaknesses")
+}
+
+// Refresh drops everything cached for the program, the next access fetches it again.
+func (h1 *Program) Refresh() {
+	cache := h1.cached()
+	cache.detail.reset()
+	cache.scopes.reset()
+	cache.weaknesses.reset()
+}
+
+// LoadedAt returns when the oldest cached data of the program was fetched, or the zero time if nothing is cached.
+// Callers can compare it against their own staleness threshold and call Refresh.
+func (h1 *Program) LoadedAt() time.Time {
+	cache := h1.cached()
+
+	var oldest time.Time
+	for _, at := range []func() (time.Time, bool){cache.detail.at, cache.scopes.at, cache.weaknesses.at} {
+		if t, ok := at(); ok && (oldest.IsZero() || t.Before(oldest)) {
+			oldest = t
+		}
+	}
+
+	return oldest
+}
+
+// cachedSeq collects every item of list into l on first use and yields them from memory.
+func cachedSeq[T any](l *lazy[[]T], list func() iter.Seq2[T, error], name string) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		items, err := l.get(func() ([]T, error) {
+			var items []T
+			for item, err := range list() {
+				if err != nil {
+					return nil, err
+				}
+				items = append(items, item)
+			}
+			return items, nil
+		})
+		if err != nil {
+			var zero T
+			yield(zero, fmt.Errorf("%s: %w", name, err))
+			return
+		}
+
+		for _, item := range items {
+			if !yield(item, nil) {
+				return
+			}
+		}
+	}
+}
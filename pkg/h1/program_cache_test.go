@@ -0,0 +1,106 @@
+package h1
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+)
+
+// lockedClient serializes calls to the wrapped client so it can be shared between goroutines.
+type lockedClient struct {
+	mu sync.Mutex
+	Client
+}
+
+func (c *lockedClient) Do(req *http.Request) (*http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.Client.Do(req)
+}
+
+func TestProgram_Scopes_Cached(t *testing.T) {
+	page := func() *http.Response {
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(`{"data": [{"id": "1"}, {"id": "2"}]}`)))}
+	}
+	mockClient := &MockClient{DoResponse: []*http.Response{page(), page()}}
+	h1 := &Hackerone{token: "token", username: "username", client: &lockedClient{Client: mockClient}}
+	p := h1.Program("security")
+
+	if !p.LoadedAt().IsZero() {
+		t.Errorf("LoadedAt() = %v before anything was loaded, want zero", p.LoadedAt())
+	}
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			count := 0
+			for _, err := range p.Scopes() {
+				if err != nil {
+					t.Errorf("Scopes() error = %v", err)
+					return
+				}
+				count++
+			}
+			if count != 2 {
+				t.Errorf("Scopes() yielded %d scopes, want 2", count)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if mockClient.CallCount != 1 {
+		t.Errorf("Do() called %d times, want 1", mockClient.CallCount)
+	}
+	if p.LoadedAt().IsZero() {
+		t.Errorf("LoadedAt() is zero after loading scopes")
+	}
+
+	p.Refresh()
+	if !p.LoadedAt().IsZero() {
+		t.Errorf("LoadedAt() = %v after Refresh, want zero", p.LoadedAt())
+	}
+	for _, err := range p.Scopes() {
+		if err != nil {
+			t.Fatalf("Scopes() error = %v", err)
+		}
+	}
+	if mockClient.CallCount != 2 {
+		t.Errorf("Do() called %d times after Refresh, want 2", mockClient.CallCount)
+	}
+}
+
+func TestProgram_Scopes_CachedWithoutConstructor(t *testing.T) {
+	page := func() *http.Response {
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(`{"data": [{"id": "1"}]}`)))}
+	}
+	mockClient := &MockClient{DoResponse: []*http.Response{page(), page()}}
+	h1 := &Hackerone{token: "token", username: "username", client: mockClient}
+
+	unmarshaled := &Program{}
+	if err := json.Unmarshal([]byte(`{"handle": "security", "id": "13"}`), unmarshaled); err != nil {
+		t.Fatal(err)
+	}
+	unmarshaled.Hackerone = h1
+
+	for name, p := range map[string]*Program{"unmarshaled": unmarshaled, "literal": {Hackerone: h1, Handle: "security"}} {
+		mockClient.CallCount = 0
+		for range 2 {
+			for _, err := range p.Scopes() {
+				if err != nil {
+					t.Fatalf("%s: Scopes() error = %v", name, err)
+				}
+			}
+		}
+		if mockClient.CallCount != 1 {
+			t.Errorf("%s: Do() called %d times, want 1", name, mockClient.CallCount)
+		}
+		if p.LoadedAt().IsZero() {
+			t.Errorf("%s: LoadedAt() is zero after loading scopes", name)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package h1
 
 import (
 	"bytes"
+	"encoding/json"
 	"io"
 	"net"
 	"net/http"
@@ -140,6 +141,75 @@ func TestProgram_GetDetail(t *testing.T) {
 	}
 }
 
+func TestProgram_GetDetailWithInput(t *testing.T) {
+	mockClient := &MockClient{
+		DoResponse: []*http.Response{
+			{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(`{"id": "13", "attributes": {"handle": "security"}, "relationships": {"structured_scopes": {"data": [{"id": "1"}]}}}`)))},
+			{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(`{"data": [{"id": "1"}, {"id": "2"}], "links": {"next": "test"}}`)))},
+			{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(`{"data": [{"id": "3", "attributes": {"asset_identifier": "example.com"}}]}`)))},
+		},
+	}
+	p := &Program{
+		Hackerone: &Hackerone{token: "token", username: "username", client: mockClient},
+		Handle:    "security",
+	}
+
+	got, err := p.GetDetailWithInput(&GetDetailInput{AllScopes: true})
+	if err != nil {
+		t.Fatalf("GetDetailWithInput() error = %v", err)
+	}
+
+	want := []types.ScopeData{
+		{Id: "1"},
+		{Id: "2"},
+		{Id: "3", Attributes: types.ScopeAttributes{AssetIdentifier: "example.com"}},
+	}
+	if diff := cmp.Diff(want, got.Relationships.StructuredScopes.Data); diff != "" {
+		t.Errorf("GetDetailWithInput() scopes mismatch (-want +got):\n%s", diff)
+	}
+	if uri := mockClient.Calls[1].URL.String(); uri != "https://api.hackerone.com/v1/hackers/programs/security/structured_scopes" {
+		t.Errorf("GetDetailWithInput() listed scopes from %s", uri)
+	}
+}
+
+func TestProgramAttributes_Extra(t *testing.T) {
+	data := []byte(`{"handle": "security", "id": "13", "type": "program", "policy": "Be nice.", "open_scope": true, "new_field": {"nested": [1, 2]}}`)
+
+	p := Program{}
+	if err := json.Unmarshal(data, &p); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	want := Program{
+		Handle: "security",
+		Id:     "13",
+		Type:   "program",
+		ProgramAttributes: types.ProgramAttributes{
+			Handle:    "security",
+			Policy:    "Be nice.",
+			OpenScope: true,
+			Extra:     map[string]json.RawMessage{"new_field": json.RawMessage(`{"nested": [1, 2]}`)},
+		},
+	}
+	if diff := cmp.Diff(want, p, cmpopts.IgnoreFields(Program{}, "Hackerone"), cmpopts.IgnoreUnexported(Program{})); diff != "" {
+		t.Errorf("Unmarshal() mismatch (-want +got):\n%s", diff)
+	}
+
+	out, err := json.Marshal(p)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	roundTrip := map[string]any{}
+	if err := json.Unmarshal(out, &roundTrip); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	for _, key := range []string{"handle", "id", "type", "policy", "open_scope", "new_field"} {
+		if _, ok := roundTrip[key]; !ok {
+			t.Errorf("Marshal() dropped %s: %s", key, out)
+		}
+	}
+}
+
 func TestProgram_Programs(t *testing.T) {
 	type fields struct {
 		Hackerone *Hackerone
@@ -252,7 +322,7 @@ func TestProgram_Programs(t *testing.T) {
 				return
 			}
 
-			if diff := cmp.Diff(tt.want, got, cmpopts.IgnoreFields(Program{}, "Hackerone")); diff != "" {
+			if diff := cmp.Diff(tt.want, got, cmpopts.IgnoreFields(Program{}, "Hackerone"), cmpopts.IgnoreUnexported(Program{})); diff != "" {
 				t.Errorf("programs() mismatch (-want +got):\n%s", diff)
 			}
 
@@ -264,6 +334,116 @@ func TestProgram_Programs(t *testing.T) {
 	}
 }
 
+func TestProgram_GetWeaknesses(t *testing.T) {
+	tests := []struct {
+		name              string
+		responses         []*http.Response
+		want              *types.Weaknesses
+		wantErr           bool
+		wantTimesDoCalled int
+	}{
+		{
+			name: "aggregates every page",
+			responses: []*http.Response{
+				{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(`{"data": [{"id": "1", "attributes": {"external_id": "cwe-79"}}], "links": {"next": "test"}}`)))},
+				{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(`{"data": [{"id": "2", "attributes": {"external_id": "cwe-89"}}]}`)))},
+			},
+			want: &types.Weaknesses{
+				Data: []types.Weakness{
+					{Id: "1", Attributes: types.WeaknessAttributes{ExternalId: "cwe-79"}},
+					{Id: "2", Attributes: types.WeaknessAttributes{ExternalId: "cwe-89"}},
+				},
+			},
+			wantTimesDoCalled: 2,
+		},
+		{
+			name: "failed page returns an error",
+			responses: []*http.Response{
+				{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(`{"data": [{"id": "1"}], "links": {"next": "test"}}`)))},
+				{StatusCode: 500, Body: io.NopCloser(bytes.NewReader([]byte(`error`)))},
+			},
+			wantErr:           true,
+			wantTimesDoCalled: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockClient{DoResponse: tt.responses}
+			p := &Program{
+				Hackerone: &Hackerone{token: "token", username: "username", client: mockClient},
+				Handle:    "security",
+			}
+
+			got, err := p.GetWeaknesses()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetWeaknesses() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("GetWeaknesses() mismatch (-want +got):\n%s", diff)
+			}
+			if mockClient.CallCount != tt.wantTimesDoCalled {
+				t.Errorf("Do() called %d times, want %d", mockClient.CallCount, tt.wantTimesDoCalled)
+			}
+		})
+	}
+}
+
+func TestProgram_ListInput(t *testing.T) {
+	tests := []struct {
+		name     string
+		list     func(p *Program, input *ListInput) error
+		wantPath string
+	}{
+		{
+			name: "structured scopes",
+			list: func(p *Program, input *ListInput) error {
+				for _, err := range p.ListStructuredScopes(input) {
+					if err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+			wantPath: "/v1/hackers/programs/security/structured_scopes",
+		},
+		{
+			name: "weaknesses",
+			list: func(p *Program, input *ListInput) error {
+				for _, err := range p.ListWeaknesses(input) {
+					if err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+			wantPath: "/v1/hackers/programs/security/weaknesses",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockClient{DoResponse: []*http.Response{
+				{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(`{"data": [{"id": "1"}]}`)))},
+			}}
+			p := (&Hackerone{token: "token", username: "username", client: mockClient}).Program("security")
+
+			var pages []PageInfo
+			if err := tt.list(p, &ListInput{PageSize: 100, OnPage: func(info PageInfo) { pages = append(pages, info) }}); err != nil {
+				t.Fatalf("list error = %v", err)
+			}
+
+			req := mockClient.Calls[0]
+			if req.URL.Path != tt.wantPath || req.URL.Query().Get("page[size]") != "100" {
+				t.Errorf("requested %s, want %s with page[size]=100", req.URL, tt.wantPath)
+			}
+			if len(pages) != 1 {
+				t.Errorf("OnPage called %d times, want 1", len(pages))
+			}
+		})
+	}
+}
+
 func TestHackerone_Retries(t *testing.T) {
 	tests := []struct {
 		name            string
@@ -381,6 +561,7 @@ func TestHackerone_Retries(t *testing.T) {
 func TestHackerone_send_Retries(t *testing.T) {
 	tests := []struct {
 		name            string
+		method          string
 		mockErrors      []error
 		mockResponses   []*http.Response
 		wantErr         bool
@@ -446,10 +627,28 @@ func TestHackerone_send_Retries(t *testing.T) {
 			wantCallCount:   1,
 			wantErrContains: "failed to send request",
 		},
+		{
+			name:   "does not retry POST on ECONNRESET",
+			method: "POST",
+			mockErrors: []error{
+				&net.OpError{Op: "read", Net: "tcp", Err: syscall.Errno(syscall.ECONNRESET)},
+				nil,
+			},
+			mockResponses: []*http.Response{{
+				StatusCode: 200,
+				Body:       io.NopCloser(bytes.NewReader([]byte(`{"links":{}}`))),
+			}},
+			wantErr:         true,
+			wantCallCount:   1,
+			wantErrContains: "connection reset by peer",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			if tt.method == "" {
+				tt.method = "GET"
+			}
 			mockClient := &MockClient{
 				DoErrors:   tt.mockErrors,
 				DoResponse: tt.mockResponses,
@@ -461,7 +660,7 @@ func TestHackerone_send_Retries(t *testing.T) {
 				client:   mockClient,
 			}
 
-			_, _, err := h1.send("GET", "https://example.com", nil)
+			_, _, err := h1.send(tt.method, "https://example.com", nil)
 
 			if (err != nil) != tt.wantErr {
 				t.Errorf("send() error = %v, wantErr %v", err, tt.wantErr)
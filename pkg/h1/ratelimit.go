@@ -0,0 +1,43 @@
+package h1
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultRequestsPerMinute is HackerOne's documented rate limit for read operations on the hacker API.
+const DefaultRequestsPerMinute = 600
+
+// rateLimiter spaces requests evenly so no more than the configured number are sent per minute, it is shared by
+// every goroutine using the same Hackerone client.
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+func newRateLimiter(perMinute int) *rateLimiter {
+	if perMinute <= 0 {
+		return nil
+	}
+
+	return &rateLimiter{interval: time.Minute / time.Duration(perMinute)}
+}
+
+// Wait blocks until the next request may be sent. A nil limiter never blocks.
+func (l *rateLimiter) Wait() {
+	if l == nil {
+		return
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	wait := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+	l.mu.Unlock()
+
+	time.Sleep(wait)
+}
@@ -0,0 +1,143 @@
+package h1
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"net/url"
+	"slices"
+
+	"github.com/ryanjarv/h1/pkg/types"
+)
+
+// MyReportsInput is the input parameters for MyReports.
+//
+// States is optional, when set only reports whose state or substate is one of these are yielded.
+// Programs is optional, when set only reports submitted to one of these program handles are yielded.
+// The API doesn't support either filter so they are applied client side, every page is still fetched.
+type MyReportsInput struct {
+	States []string `json:"states,omitempty"`
+
+	Programs []string `json:"programs,omitempty"`
+
+	ListInput
+}
+
+// MyReports iterates over the reports submitted by the authenticated user, input is optional.
+func (h1 *Hackerone) MyReports(input *MyReportsInput) iter.Seq2[types.Report, error] {
+	if input == nil {
+		input = &MyReportsInput{}
+	}
+
+	reports := Paginate[types.Report](h1, &PaginateInput{
+		Uri:       "https://api.hackerone.com/v1/hackers/me/reports",
+		ListInput: input.ListInput,
+	})
+
+	return func(yield func(types.Report, error) bool) {
+		for report, err := range reports {
+			if err != nil {
+				yield(report, fmt.Errorf("MyReports: %w", err))
+				return
+			}
+
+			if !input.matches(&report) {
+				continue
+			} else if !yield(report, nil) {
+				return
+			}
+		}
+	}
+}
+
+func (input *MyReportsInput) matches(report *types.Report) bool {
+	if len(input.States) != 0 &&
+		!slices.Contains(input.States, report.Attributes.State) &&
+		!slices.Contains(input.States, report.Attributes.Substate) {
+		return false
+	}
+
+	handle := report.Relationships.Program.Data.Attributes.Handle
+	if len(input.Programs) != 0 && !slices.Contains(input.Programs, handle) {
+		return false
+	}
+
+	return true
+}
+
+func (h1 *Hackerone) Report(id string) *Report {
+	return &Report{
+		Hackerone: h1,
+		Id:        id,
+	}
+}
+
+type Report struct {
+	*Hackerone `json:"-"`
+
+	// Id is required for fetching report details.
+	Id string `json:"id"`
+}
+
+// GetDetail returns the report along with its activities, attachments, structured scope, weakness and severity.
+func (h1 *Report) GetDetail() (*types.ReportDetail, error) {
+	uri := fmt.Sprintf("https://api.hackerone.com/v1/hackers/reports/%s", url.PathEscape(h1.Id))
+
+	resp, uri, err := h1.send("GET", uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("GetDetail: getting report: %w", err)
+	} else if uri != "" {
+		return nil, fmt.Errorf("GetDetail: unexpected pagination for single report: %s", uri)
+	}
+
+	report := types.ReportDetail{}
+	err = json.Unmarshal(resp, &report)
+	if err != nil {
+		return nil, fmt.Errorf("GetDetail: failed to unmarshal report: %w", err)
+	}
+
+	return &report, nil
+}
+
+// Comment posts a comment on the report, attachments are optional paths of files to upload along with it.
+func (h1 *Report) Comment(message string, attachments ...string) (*types.Activity, error) {
+	uri := fmt.Sprintf("https://api.hackerone.com/v1/hackers/reports/%s/activities", url.PathEscape(h1.Id))
+
+	var resp []byte
+	var err error
+	if len(attachments) == 0 {
+		payload := map[string]any{
+			"data": map[string]any{
+				"type":       "activity-comment",
+				"attributes": map[string]any{"message": message},
+			},
+		}
+
+		var body []byte
+		if body, err = json.Marshal(payload); err != nil {
+			return nil, fmt.Errorf("Comment: failed to marshal comment: %w", err)
+		}
+		resp, _, err = h1.send("POST", uri, bytes.NewReader(body))
+	} else {
+		var files []formFile
+		if files, err = attachmentFiles(attachments); err != nil {
+			return nil, fmt.Errorf("Comment: %w", err)
+		}
+		fields := []formField{
+			{name: "data[type]", value: "activity-comment"},
+			{name: "data[attributes][message]", value: message},
+		}
+		resp, _, err = h1.sendWith("POST", uri, multipartBody(fields, files))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Comment: posting comment: %w", err)
+	}
+
+	activity := types.Activity{}
+	if err := json.Unmarshal(resp, &activity); err != nil {
+		return nil, fmt.Errorf("Comment: failed to unmarshal activity: %w", err)
+	}
+
+	return &activity, nil
+}
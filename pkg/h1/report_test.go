@@ -0,0 +1,148 @@
+package h1
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestHackerone_MyReports(t *testing.T) {
+	body := `{"data": [
+  {"id": "1", "type": "report", "attributes": {"title": "XSS", "state": "open", "substate": "triaged"},
+   "relationships": {"program": {"data": {"id": "13", "type": "program", "attributes": {"handle": "security"}}},
+                     "bounties": {"data": [{"id": "7", "attributes": {"awarded_amount": "500.00", "awarded_bonus_amount": "50.00"}}]}}},
+  {"id": "2", "type": "report", "attributes": {"title": "SQLi", "state": "open", "substate": "new"},
+   "relationships": {"program": {"data": {"id": "13", "type": "program", "attributes": {"handle": "security"}}}}},
+  {"id": "3", "type": "report", "attributes": {"title": "SSRF", "state": "closed", "substate": "resolved"},
+   "relationships": {"program": {"data": {"id": "14", "type": "program", "attributes": {"handle": "other"}}}}}
+]}`
+
+	tests := []struct {
+		name  string
+		input *MyReportsInput
+		want  []string
+	}{
+		{name: "no filters", input: nil, want: []string{"1", "2", "3"}},
+		{name: "by substate", input: &MyReportsInput{States: []string{"triaged", "resolved"}}, want: []string{"1", "3"}},
+		{name: "by state", input: &MyReportsInput{States: []string{"open"}}, want: []string{"1", "2"}},
+		{name: "by program", input: &MyReportsInput{Programs: []string{"other"}}, want: []string{"3"}},
+		{name: "by state and program", input: &MyReportsInput{States: []string{"new"}, Programs: []string{"security"}}, want: []string{"2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h1 := &Hackerone{token: "token", username: "username", client: &MockClient{
+				DoResponse: []*http.Response{{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(body)))}},
+			}}
+
+			var got []string
+			for report, err := range h1.MyReports(tt.input) {
+				if err != nil {
+					t.Fatalf("MyReports() error = %v", err)
+				}
+				got = append(got, report.Id)
+
+				if report.Id == "1" {
+					bounty := report.Relationships.Bounties.Data[0].Attributes
+					if total := bounty.AwardedAmount.Float64() + bounty.AwardedBonusAmount.Float64(); total != 550 {
+						t.Errorf("MyReports() bounty total = %v, want 550", total)
+					}
+				}
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("MyReports() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReport_GetDetail(t *testing.T) {
+	mockClient := &MockClient{
+		DoResponse: []*http.Response{{
+			StatusCode: 200,
+			Body: io.NopCloser(bytes.NewReader([]byte(`
+{
+  "id": "1337",
+  "type": "report",
+  "attributes": {"title": "XSS in search", "state": "open", "substate": "triaged", "created_at": "2024-01-02T03:04:05.000Z"},
+  "relationships": {
+    "program": {"data": {"id": "13", "type": "program", "attributes": {"handle": "security"}}},
+    "weakness": {"data": {"id": "60", "type": "weakness", "attributes": {"name": "Cross-site Scripting (XSS) - Reflected", "external_id": "cwe-79"}}},
+    "severity": {"data": {"id": "5", "type": "severity", "attributes": {"rating": "medium"}}},
+    "structured_scope": {"data": {"id": "131858", "type": "structured-scope", "attributes": {"asset_type": "URL", "asset_identifier": "www.example.com"}}},
+    "activities": {"data": [
+      {"id": "1", "type": "activity-bug-triaged", "attributes": {"message": "Thanks!"},
+       "relationships": {"actor": {"data": {"id": "2", "type": "user", "attributes": {"username": "triager"}}}}},
+      {"id": "2", "type": "activity-bounty-awarded", "attributes": {"message": "", "bounty_amount": "500.0", "bonus_amount": "0.0"},
+       "relationships": {"actor": {"data": {"id": "13", "type": "program", "attributes": {"handle": "security"}}}}}
+    ]},
+    "attachments": {"data": [{"id": "9", "type": "attachment", "attributes": {"file_name": "poc.png", "content_type": "image/png", "file_size": 1024}}]}
+  }
+}`))),
+		}},
+	}
+	h1 := &Hackerone{token: "token", username: "username", client: mockClient}
+
+	got, err := h1.Report("1337").GetDetail()
+	if err != nil {
+		t.Fatalf("GetDetail() error = %v", err)
+	}
+
+	if uri := mockClient.Calls[0].URL.String(); uri != "https://api.hackerone.com/v1/hackers/reports/1337" {
+		t.Errorf("GetDetail() requested %s", uri)
+	}
+	if got.Relationships.Program.Data.Attributes.Handle != "security" {
+		t.Errorf("GetDetail() program = %q, want security", got.Relationships.Program.Data.Attributes.Handle)
+	}
+	if got.Relationships.Weakness.Data.Attributes.ExternalId != "cwe-79" {
+		t.Errorf("GetDetail() weakness = %+v, want cwe-79", got.Relationships.Weakness.Data)
+	}
+	if got.Relationships.Severity.Data.Attributes.Rating != "medium" {
+		t.Errorf("GetDetail() severity = %+v, want medium", got.Relationships.Severity.Data)
+	}
+	if got.Relationships.StructuredScope.Data.Attributes.AssetIdentifier != "www.example.com" {
+		t.Errorf("GetDetail() scope = %+v, want www.example.com", got.Relationships.StructuredScope.Data)
+	}
+
+	var activities []string
+	for _, activity := range got.Relationships.Activities.Data {
+		actor := activity.Relationships.Actor.Data.Attributes
+		activities = append(activities, activity.Type+" by "+actor.Username+actor.Handle)
+	}
+	want := []string{"activity-bug-triaged by triager", "activity-bounty-awarded by security"}
+	if diff := cmp.Diff(want, activities); diff != "" {
+		t.Errorf("GetDetail() activities mismatch (-want +got):\n%s", diff)
+	}
+	if n := len(got.Relationships.Attachments.Data); n != 1 {
+		t.Errorf("GetDetail() returned %d attachments, want 1", n)
+	}
+}
+
+func TestReport_EscapesId(t *testing.T) {
+	response := func() *http.Response {
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(`{"id": "1", "type": "report"}`)))}
+	}
+	mockClient := &MockClient{DoResponse: []*http.Response{response(), response()}}
+	h1 := &Hackerone{token: "token", username: "username", client: mockClient}
+
+	report := h1.Report("1337/../../programs")
+	if _, err := report.GetDetail(); err != nil {
+		t.Fatalf("GetDetail() error = %v", err)
+	}
+	if _, err := report.Comment("Thanks!"); err != nil {
+		t.Fatalf("Comment() error = %v", err)
+	}
+
+	want := []string{"/v1/hackers/reports/1337%2F..%2F..%2Fprograms", "/v1/hackers/reports/1337%2F..%2F..%2Fprograms/activities"}
+	var got []string
+	for _, call := range mockClient.Calls {
+		got = append(got, call.URL.EscapedPath())
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("requested paths mismatch (-want +got):\n%s", diff)
+	}
+}
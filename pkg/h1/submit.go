@@ -0,0 +1,288 @@
+package h1
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ryanjarv/h1/pkg/types"
+)
+
+// ErrInvalidDraft is wrapped by every error returned when a report draft fails validation.
+var ErrInvalidDraft = errors.New("invalid report draft")
+
+// severityRanks orders the severity ratings accepted by the API from lowest to highest.
+var severityRanks = map[string]int{
+	"none":     0,
+	"low":      1,
+	"medium":   2,
+	"high":     3,
+	"critical": 4,
+}
+
+// ReportDraft is a report that has not been submitted yet, use NewReportDraft and the With methods to build one.
+//
+// Title, VulnerabilityInformation and Impact must be provided.
+// WeaknessId and StructuredScopeId are optional, when set they must belong to the program the draft is submitted to.
+// SeverityRating is optional and is one of none, low, medium, high or critical.
+// Attachments is optional and holds the paths of files to upload with the report.
+type ReportDraft struct {
+	Title string `json:"title"`
+
+	VulnerabilityInformation string `json:"vulnerability_information"`
+
+	Impact string `json:"impact"`
+
+	WeaknessId string `json:"weakness_id,omitempty"`
+
+	StructuredScopeId string `json:"structured_scope_id,omitempty"`
+
+	SeverityRating string `json:"severity_rating,omitempty"`
+
+	Attachments []string `json:"attachments,omitempty"`
+}
+
+func NewReportDraft(title string) *ReportDraft {
+	return &ReportDraft{Title: title}
+}
+
+func (d *ReportDraft) WithVulnerabilityInformation(info string) *ReportDraft {
+	d.VulnerabilityInformation = info
+	return d
+}
+
+func (d *ReportDraft) WithImpact(impact string) *ReportDraft {
+	d.Impact = impact
+	return d
+}
+
+func (d *ReportDraft) WithWeakness(id string) *ReportDraft {
+	d.WeaknessId = id
+	return d
+}
+
+func (d *ReportDraft) WithStructuredScope(id string) *ReportDraft {
+	d.StructuredScopeId = id
+	return d
+}
+
+func (d *ReportDraft) WithSeverity(rating string) *ReportDraft {
+	d.SeverityRating = strings.ToLower(rating)
+	return d
+}
+
+func (d *ReportDraft) WithAttachment(path string) *ReportDraft {
+	d.Attachments = append(d.Attachments, path)
+	return d
+}
+
+// Validate checks the fields of the draft that don't depend on the program, every problem is reported at once.
+func (d *ReportDraft) Validate() error {
+	var errs []error
+	if strings.TrimSpace(d.Title) == "" {
+		errs = append(errs, errors.New("title is required"))
+	}
+	if strings.TrimSpace(d.VulnerabilityInformation) == "" {
+		errs = append(errs, errors.New("vulnerability information is required"))
+	}
+	if strings.TrimSpace(d.Impact) == "" {
+		errs = append(errs, errors.New("impact is required"))
+	}
+	if _, ok := severityRanks[d.SeverityRating]; d.SeverityRating != "" && !ok {
+		errs = append(errs, fmt.Errorf("unknown severity rating %q", d.SeverityRating))
+	}
+	if _, err := strconv.Atoi(d.WeaknessId); d.WeaknessId != "" && err != nil {
+		errs = append(errs, fmt.Errorf("weakness id %q is not a number", d.WeaknessId))
+	}
+	if _, err := strconv.Atoi(d.StructuredScopeId); d.StructuredScopeId != "" && err != nil {
+		errs = append(errs, fmt.Errorf("structured scope id %q is not a number", d.StructuredScopeId))
+	}
+	for _, path := range d.Attachments {
+		if _, err := NewAttachment(path); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) != 0 {
+		return fmt.Errorf("%w: %w", ErrInvalidDraft, errors.Join(errs...))
+	}
+	return nil
+}
+
+// ValidateFor checks the draft against the program: the weakness must be one of the program's weaknesses, the
+// structured scope must be one of its scopes and eligible for submission, and the severity must not exceed the
+// scope's max severity.
+func (d *ReportDraft) ValidateFor(program *Program) error {
+	if err := d.Validate(); err != nil {
+		return err
+	}
+
+	var errs []error
+	if d.WeaknessId != "" {
+		found := false
+		for weakness, err := range program.Weaknesses() {
+			if err != nil {
+				return fmt.Errorf("validating weakness: %w", err)
+			} else if weakness.Id == d.WeaknessId {
+				found = true
+				break
+			}
+		}
+		if !found {
+			errs = append(errs, fmt.Errorf("weakness %s is not accepted by %s", d.WeaknessId, program.Handle))
+		}
+	}
+
+	if d.StructuredScopeId != "" {
+		var scope *types.ScopeData
+		for s, err := range program.Scopes() {
+			if err != nil {
+				return fmt.Errorf("validating structured scope: %w", err)
+			} else if s.Id == d.StructuredScopeId {
+				scope = &s
+				break
+			}
+		}
+
+		if scope == nil {
+			errs = append(errs, fmt.Errorf("structured scope %s is not in scope for %s", d.StructuredScopeId, program.Handle))
+		} else {
+			errs = append(errs, d.validateScope(scope)...)
+		}
+	}
+
+	if len(errs) != 0 {
+		return fmt.Errorf("%w: %w", ErrInvalidDraft, errors.Join(errs...))
+	}
+	return nil
+}
+
+func (d *ReportDraft) validateScope(scope *types.ScopeData) []error {
+	var errs []error
+	if !scope.Attributes.EligibleForSubmission {
+		errs = append(errs, fmt.Errorf("structured scope %s (%s) is not eligible for submission", scope.Id, scope.Attributes.AssetIdentifier))
+	}
+
+	maxRank, ok := severityRanks[strings.ToLower(scope.Attributes.MaxSeverity)]
+	if ok && d.SeverityRating != "" && severityRanks[d.SeverityRating] > maxRank {
+		errs = append(errs, fmt.Errorf("severity %s exceeds the max severity %s of structured scope %s", d.SeverityRating, scope.Attributes.MaxSeverity, scope.Id))
+	}
+
+	return errs
+}
+
+// SubmitReport validates the draft against the program with ValidateFor and submits it, returning the new report.
+func (h1 *Program) SubmitReport(draft *ReportDraft) (*types.ReportDetail, error) {
+	if err := draft.ValidateFor(h1); err != nil {
+		return nil, fmt.Errorf("SubmitReport: %w", err)
+	}
+
+	return h1.submitReport(draft)
+}
+
+// submitReport submits a draft that has already been validated.
+func (h1 *Program) submitReport(draft *ReportDraft) (*types.ReportDetail, error) {
+	uri := "https://api.hackerone.com/v1/hackers/reports"
+	payload := draft.payload(h1.Handle)
+
+	var resp []byte
+	var err error
+	if len(draft.Attachments) == 0 {
+		var body []byte
+		if body, err = json.Marshal(payload); err != nil {
+			return nil, fmt.Errorf("SubmitReport: failed to marshal report: %w", err)
+		}
+		resp, _, err = h1.send("POST", uri, bytes.NewReader(body))
+	} else {
+		var files []formFile
+		if files, err = attachmentFiles(draft.Attachments); err != nil {
+			return nil, fmt.Errorf("SubmitReport: %w", err)
+		}
+		resp, _, err = h1.sendWith("POST", uri, multipartBody(payload.fields(), files))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("SubmitReport: submitting report: %w", err)
+	}
+
+	report := types.ReportDetail{}
+	if err := json.Unmarshal(resp, &report); err != nil {
+		return nil, fmt.Errorf("SubmitReport: failed to unmarshal report: %w", err)
+	}
+
+	return &report, nil
+}
+
+type reportPayload struct {
+	Data struct {
+		Type       string                  `json:"type"`
+		Attributes reportPayloadAttributes `json:"attributes"`
+	} `json:"data"`
+}
+
+type reportPayloadAttributes struct {
+	TeamHandle               string `json:"team_handle"`
+	Title                    string `json:"title"`
+	VulnerabilityInformation string `json:"vulnerability_information"`
+	Impact                   string `json:"impact"`
+	SeverityRating           string `json:"severity_rating,omitempty"`
+	WeaknessId               int    `json:"weakness_id,omitempty"`
+	StructuredScopeId        int    `json:"structured_scope_id,omitempty"`
+}
+
+// fields returns the payload as form fields for multipart requests, using the API's bracketed field names.
+func (p *reportPayload) fields() []formField {
+	attrs := p.Data.Attributes
+	fields := []formField{
+		{name: "data[type]", value: p.Data.Type},
+		{name: "data[attributes][team_handle]", value: attrs.TeamHandle},
+		{name: "data[attributes][title]", value: attrs.Title},
+		{name: "data[attributes][vulnerability_information]", value: attrs.VulnerabilityInformation},
+		{name: "data[attributes][impact]", value: attrs.Impact},
+	}
+	if attrs.SeverityRating != "" {
+		fields = append(fields, formField{name: "data[attributes][severity_rating]", value: attrs.SeverityRating})
+	}
+	if attrs.WeaknessId != 0 {
+		fields = append(fields, formField{name: "data[attributes][weakness_id]", value: strconv.Itoa(attrs.WeaknessId)})
+	}
+	if attrs.StructuredScopeId != 0 {
+		fields = append(fields, formField{name: "data[attributes][structured_scope_id]", value: strconv.Itoa(attrs.StructuredScopeId)})
+	}
+	return fields
+}
+
+// attachmentFiles checks every path and returns them as files for a multipart request.
+func attachmentFiles(paths []string) ([]formFile, error) {
+	files := make([]formFile, 0, len(paths))
+	for _, path := range paths {
+		attachment, err := NewAttachment(path)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, formFile{name: "data[attributes][attachments][]", attachment: attachment})
+	}
+	return files, nil
+}
+
+// payload returns the request body for submitting the draft, ids have already been checked by Validate.
+func (d *ReportDraft) payload(handle string) *reportPayload {
+	weaknessId, _ := strconv.Atoi(d.WeaknessId)
+	scopeId, _ := strconv.Atoi(d.StructuredScopeId)
+
+	payload := &reportPayload{}
+	payload.Data.Type = "report"
+	payload.Data.Attributes = reportPayloadAttributes{
+		TeamHandle:               handle,
+		Title:                    d.Title,
+		VulnerabilityInformation: d.VulnerabilityInformation,
+		Impact:                   d.Impact,
+		SeverityRating:           d.SeverityRating,
+		WeaknessId:               weaknessId,
+		StructuredScopeId:        scopeId,
+	}
+
+	return payload
+}
@@ -0,0 +1,133 @@
+package h1
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestProgram_SubmitReport(t *testing.T) {
+	weaknesses := `{"data": [{"id": "60", "attributes": {"name": "Cross-site Scripting (XSS) - Reflected", "external_id": "cwe-79"}}]}`
+	scopes := `{"data": [
+  {"id": "1", "attributes": {"asset_identifier": "www.example.com", "eligible_for_submission": true, "max_severity": "high"}},
+  {"id": "2", "attributes": {"asset_identifier": "legacy.example.com", "eligible_for_submission": false, "max_severity": "critical"}}
+]}`
+	created := `{"id": "1337", "type": "report", "attributes": {"title": "XSS in search", "state": "open", "substate": "new"}}`
+
+	draft := func() *ReportDraft {
+		return NewReportDraft("XSS in search").
+			WithVulnerabilityInformation("The q parameter is reflected unescaped.").
+			WithImpact("Session hijacking.").
+			WithWeakness("60").
+			WithStructuredScope("1").
+			WithSeverity("High")
+	}
+
+	tests := []struct {
+		name              string
+		draft             *ReportDraft
+		responses         []string
+		wantId            string
+		wantInvalid       bool
+		wantTimesDoCalled int
+	}{
+		{
+			name:              "valid draft is submitted",
+			draft:             draft(),
+			responses:         []string{weaknesses, scopes, created},
+			wantId:            "1337",
+			wantTimesDoCalled: 3,
+		},
+		{
+			name:              "missing fields are rejected without any request",
+			draft:             NewReportDraft("XSS in search"),
+			wantInvalid:       true,
+			wantTimesDoCalled: 0,
+		},
+		{
+			name:              "unknown weakness",
+			draft:             draft().WithWeakness("61"),
+			responses:         []string{weaknesses, scopes},
+			wantInvalid:       true,
+			wantTimesDoCalled: 2,
+		},
+		{
+			name:              "scope not eligible for submission",
+			draft:             draft().WithStructuredScope("2"),
+			responses:         []string{weaknesses, scopes},
+			wantInvalid:       true,
+			wantTimesDoCalled: 2,
+		},
+		{
+			name:              "severity above the scope's max severity",
+			draft:             draft().WithSeverity("critical"),
+			responses:         []string{weaknesses, scopes},
+			wantInvalid:       true,
+			wantTimesDoCalled: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockClient{}
+			for _, body := range tt.responses {
+				mockClient.DoResponse = append(mockClient.DoResponse, &http.Response{
+					StatusCode: 201,
+					Body:       io.NopCloser(bytes.NewReader([]byte(body))),
+				})
+			}
+			p := &Program{
+				Hackerone: &Hackerone{token: "token", username: "username", client: mockClient},
+				Handle:    "security",
+			}
+
+			got, err := p.SubmitReport(tt.draft)
+			if errors.Is(err, ErrInvalidDraft) != tt.wantInvalid {
+				t.Fatalf("SubmitReport() error = %v, wantInvalid %v", err, tt.wantInvalid)
+			} else if !tt.wantInvalid && err != nil {
+				t.Fatalf("SubmitReport() error = %v", err)
+			}
+			if mockClient.CallCount != tt.wantTimesDoCalled {
+				t.Errorf("Do() called %d times, want %d", mockClient.CallCount, tt.wantTimesDoCalled)
+			}
+			if tt.wantInvalid {
+				return
+			}
+
+			if got.Id != tt.wantId {
+				t.Errorf("SubmitReport() id = %s, want %s", got.Id, tt.wantId)
+			}
+
+			req := mockClient.Calls[len(mockClient.Calls)-1]
+			if req.Method != "POST" || req.Header.Get("Content-Type") != "application/json" {
+				t.Errorf("SubmitReport() sent %s with content type %q", req.Method, req.Header.Get("Content-Type"))
+			}
+			sent := map[string]any{}
+			if err := json.NewDecoder(req.Body).Decode(&sent); err != nil {
+				t.Fatalf("decoding request body: %s", err)
+			}
+			want := map[string]any{
+				"data": map[string]any{
+					"type": "report",
+					"attributes": map[string]any{
+						"team_handle":               "security",
+						"title":                     "XSS in search",
+						"vulnerability_information": "The q parameter is reflected unescaped.",
+						"impact":                    "Session hijacking.",
+						"severity_rating":           "high",
+						"weakness_id":               float64(60),
+						"structured_scope_id":       float64(1),
+					},
+				},
+			}
+			if diff := cmp.Diff(want, sent); diff != "" {
+				t.Errorf("SubmitReport() body mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package h1
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+	"text/template"
+)
+
+// defaultTemplate is the template used for weaknesses without one of their own, its sections are also inherited by
+// templates that don't define every section.
+const defaultTemplate = "default"
+
+//go:embed templates/*.tmpl
+var builtinTemplates embed.FS
+
+// TemplateData is what report templates are executed with.
+type TemplateData struct {
+	Program string
+
+	Asset string
+
+	Weakness string
+
+	ExternalId string
+}
+
+// RenderedTemplate holds the sections of a report rendered from a template.
+type RenderedTemplate struct {
+	Summary          string
+	StepsToReproduce string
+	Impact           string
+	Remediation      string
+}
+
+// ReportTemplates are text/template report templates keyed by weakness external id, e.g. cwe-79.
+//
+// Every template is a file named after the external id with a .tmpl extension that defines the summary, steps,
+// impact and remediation templates. Sections a template doesn't define are taken from default.tmpl.
+type ReportTemplates struct {
+	templates map[string]*template.Template
+}
+
+// LoadTemplates loads the built-in templates, then the templates in dir which replace built-in templates with the
+// same name. dir is optional, when empty only the built-in templates are loaded.
+func LoadTemplates(dir string) (*ReportTemplates, error) {
+	sources, err := readTemplates(builtinTemplates, "templates")
+	if err != nil {
+		return nil, fmt.Errorf("LoadTemplates: built-in templates: %w", err)
+	}
+
+	if dir != "" {
+		overrides, err := readTemplates(os.DirFS(dir), ".")
+		if err != nil {
+			return nil, fmt.Errorf("LoadTemplates: %s: %w", dir, err)
+		}
+		for name, source := range overrides {
+			sources[name] = source
+		}
+	}
+
+	base, err := template.New(defaultTemplate).Option("missingkey=error").Parse(sources[defaultTemplate])
+	if err != nil {
+		return nil, fmt.Errorf("LoadTemplates: %w", err)
+	}
+
+	t := &ReportTemplates{templates: map[string]*template.Template{defaultTemplate: base}}
+	for name, source := range sources {
+		if name == defaultTemplate {
+			continue
+		}
+		clone, err := base.Clone()
+		if err != nil {
+			return nil, fmt.Errorf("LoadTemplates: %w", err)
+		}
+		if t.templates[name], err = clone.New(name).Parse(source); err != nil {
+			return nil, fmt.Errorf("LoadTemplates: %w", err)
+		}
+	}
+
+	return t, nil
+}
+
+// Has reports whether there is a template for the weakness rather than only the default one.
+func (t *ReportTemplates) Has(externalId string) bool {
+	_, ok := t.templates[strings.ToLower(externalId)]
+	return ok
+}
+
+// Render executes the template of the weakness identified by externalId, falling back to the default template.
+func (t *ReportTemplates) Render(externalId string, data *TemplateData) (*RenderedTemplate, error) {
+	tmpl, ok := t.templates[strings.ToLower(externalId)]
+	if !ok {
+		tmpl = t.templates[defaultTemplate]
+	}
+
+	rendered := &RenderedTemplate{}
+	for _, section := range []struct {
+		name string
+		dst  *string
+	}{
+		{"summary", &rendered.Summary},
+		{"steps", &rendered.StepsToReproduce},
+		{"impact", &rendered.Impact},
+		{"remediation", &rendered.Remediation},
+	} {
+		var b strings.Builder
+		if err := tmpl.ExecuteTemplate(&b, section.name, data); err != nil {
+			return nil, fmt.Errorf("Render: %w", err)
+		}
+		*section.dst = strings.TrimSpace(b.String())
+	}
+
+	return rendered, nil
+}
+
+// VulnerabilityInformation joins the summary, steps to reproduce and remediation as the Markdown body of a report.
+func (r *RenderedTemplate) VulnerabilityInformation() string {
+	return fmt.Sprintf("## Summary\n\n%s\n\n## Steps to reproduce\n\n%s\n\n## Remediation\n\n%s\n", r.Summary, r.StepsToReproduce, r.Remediation)
+}
+
+// DraftFromTemplate starts a draft for the program pre-filled from the template of the weakness. The weakness and
+// structured scope are looked up among the program's weaknesses and scopes, scopeId is optional.
+func (h1 *Program) DraftFromTemplate(templates *ReportTemplates, title, weaknessId, scopeId string) (*ReportDraft, error) {
+	data := &TemplateData{Program: h1.Handle, Asset: h1.Handle}
+
+	found := false
+	for weakness, err := range h1.Weaknesses() {
+		if err != nil {
+			return nil, fmt.Errorf("DraftFromTemplate: %w", err)
+		} else if weakness.Id == weaknessId {
+			data.Weakness, data.ExternalId, found = weakness.Attributes.Name, weakness.Attributes.ExternalId, true
+			break
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("DraftFromTemplate: %w: weakness %s is not accepted by %s", ErrInvalidDraft, weaknessId, h1.Handle)
+	}
+
+	if scopeId != "" {
+		found = false
+		for scope, err := range h1.Scopes() {
+			if err != nil {
+				return nil, fmt.Errorf("DraftFromTemplate: %w", err)
+			} else if scope.Id == scopeId {
+				data.Asset, found = scope.Attributes.AssetIdentifier, true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("DraftFromTemplate: %w: structured scope %s is not in scope for %s", ErrInvalidDraft, scopeId, h1.Handle)
+		}
+	}
+
+	rendered, err := templates.Render(data.ExternalId, data)
+	if err != nil {
+		return nil, fmt.Errorf("DraftFromTemplate: %w", err)
+	}
+
+	draft := NewReportDraft(title).
+		WithVulnerabilityInformation(rendered.VulnerabilityInformation()).
+		WithImpact(rendered.Impact).
+		WithWeakness(weaknessId)
+	if scopeId != "" {
+		draft.WithStructuredScope(scopeId)
+	}
+
+	return draft, nil
+}
+
+// readTemplates returns the source of every .tmpl file in dir keyed by its lowercased name without the extension.
+func readTemplates(fsys fs.FS, dir string) (map[string]string, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	sources := map[string]string{}
+	for _, entry := range entries {
+		if entry.IsDir() || path.Ext(entry.Name()) != ".tmpl" {
+			continue
+		}
+		data, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		sources[strings.ToLower(strings.TrimSuffix(entry.Name(), ".tmpl"))] = string(data)
+	}
+
+	return sources, nil
+}
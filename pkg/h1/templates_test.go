@@ -0,0 +1,91 @@
+package h1
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLoadTemplates(t *testing.T) {
+	dir := t.TempDir()
+	override := `{{define "summary"}}Custom XSS on {{.Asset}}.{{end}}`
+	if err := os.WriteFile(filepath.Join(dir, "CWE-79.tmpl"), []byte(override), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	templates, err := LoadTemplates(dir)
+	if err != nil {
+		t.Fatalf("LoadTemplates() error = %v", err)
+	}
+	data := &TemplateData{Program: "security", Asset: "www.example.com", Weakness: "Cross-site Scripting (XSS) - Reflected", ExternalId: "cwe-79"}
+
+	got, err := templates.Render("cwe-79", data)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	// Sections the override doesn't define come from the default template.
+	want := &RenderedTemplate{
+		Summary:          "Custom XSS on www.example.com.",
+		StepsToReproduce: "1. Navigate to www.example.com.\n2. <!-- Describe the request or action that triggers the issue. -->\n3. Observe the result.",
+		Impact:           "<!-- Describe what an attacker could achieve against security and its users. -->",
+		Remediation:      "<!-- Describe how the Cross-site Scripting (XSS) - Reflected issue could be fixed. -->",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Render() mismatch (-want +got):\n%s", diff)
+	}
+
+	got, err = templates.Render("cwe-89", data)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if !strings.HasPrefix(got.Summary, "www.example.com builds a database query") {
+		t.Errorf("Render() summary = %q, want the built-in cwe-89 template", got.Summary)
+	}
+
+	if templates.Has("cwe-1") {
+		t.Error("Has(cwe-1) = true, want false")
+	}
+	if _, err := LoadTemplates(filepath.Join(dir, "missing")); err == nil {
+		t.Error("LoadTemplates() with a missing directory succeeded")
+	}
+}
+
+func TestProgram_DraftFromTemplate(t *testing.T) {
+	client := clientFunc(func(req *http.Request) (*http.Response, error) {
+		body := `{"data": [{"id": "60", "attributes": {"name": "Cross-site Scripting (XSS) - Reflected", "external_id": "cwe-79"}}]}`
+		if strings.HasSuffix(req.URL.Path, "/structured_scopes") {
+			body = `{"data": [{"id": "1", "attributes": {"asset_identifier": "www.example.com"}}]}`
+		}
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(body)))}, nil
+	})
+	p := (&Hackerone{token: "token", username: "username", client: client}).Program("security")
+
+	templates, err := LoadTemplates("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	draft, err := p.DraftFromTemplate(templates, "XSS in search", "60", "1")
+	if err != nil {
+		t.Fatalf("DraftFromTemplate() error = %v", err)
+	}
+	if draft.WeaknessId != "60" || draft.StructuredScopeId != "1" {
+		t.Errorf("DraftFromTemplate() = %+v", draft)
+	}
+	if !strings.HasPrefix(draft.VulnerabilityInformation, "## Summary\n\nwww.example.com reflects user-controlled input") {
+		t.Errorf("DraftFromTemplate() vulnerability information = %q", draft.VulnerabilityInformation)
+	}
+	if err := draft.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+
+	if _, err := p.DraftFromTemplate(templates, "XSS in search", "61", ""); err == nil {
+		t.Error("DraftFromTemplate() with an unknown weakness succeeded")
+	}
+}
@@ -0,0 +1,212 @@
+package h1
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ryanjarv/h1/pkg/types"
+)
+
+// activityDescriptions describe the common activity types, others are derived from the type name.
+var activityDescriptions = map[string]string{
+	"activity-comment":                 "commented",
+	"activity-bug-new":                 "changed the status to New",
+	"activity-bug-triaged":             "changed the status to Triaged",
+	"activity-bug-needs-more-info":     "changed the status to Needs more info",
+	"activity-bug-resolved":            "closed the report as Resolved",
+	"activity-bug-duplicate":           "closed the report as Duplicate",
+	"activity-bug-informative":         "closed the report as Informative",
+	"activity-bug-not-applicable":      "closed the report as Not applicable",
+	"activity-bug-spam":                "closed the report as Spam",
+	"activity-bug-reopened":            "reopened the report",
+	"activity-bounty-awarded":          "awarded a bounty",
+	"activity-swag-awarded":            "awarded swag",
+	"activity-report-severity-updated": "updated the severity",
+	"activity-agreed-on-going-public":  "agreed on going public",
+	"activity-report-became-public":    "disclosed the report",
+}
+
+// timelineEntry is a single rendered activity, shared by the Markdown and plain text renderers.
+type timelineEntry struct {
+	at       time.Time
+	actor    string
+	action   string
+	message  string
+	internal bool
+	files    []string
+}
+
+// RenderMarkdown writes the report as a Markdown document: a header with the program, severity, weakness and state,
+// followed by every activity in chronological order.
+func RenderMarkdown(w io.Writer, report *types.ReportDetail) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# %s\n\n", report.Attributes.Title)
+	for _, field := range reportHeader(report) {
+		fmt.Fprintf(&b, "- **%s:** %s\n", field[0], field[1])
+	}
+
+	for _, entry := range timeline(report) {
+		fmt.Fprintf(&b, "\n## %s — %s %s\n", entry.at.UTC().Format("2006-01-02 15:04 MST"), entry.actor, entry.action)
+		if entry.internal {
+			b.WriteString("\n_Internal_\n")
+		}
+		if entry.message != "" {
+			fmt.Fprintf(&b, "\n%s\n", strings.TrimSpace(entry.message))
+		}
+		if len(entry.files) != 0 {
+			b.WriteString("\nAttachments:\n")
+			for _, file := range entry.files {
+				fmt.Fprintf(&b, "- %s\n", file)
+			}
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// RenderText writes the same timeline as RenderMarkdown without any markup, messages are indented under their entry.
+func RenderText(w io.Writer, report *types.ReportDetail) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%s\n%s\n\n", report.Attributes.Title, strings.Repeat("=", utf8.RuneCountInString(report.Attributes.Title)))
+	for _, field := range reportHeader(report) {
+		fmt.Fprintf(&b, "%s: %s\n", field[0], field[1])
+	}
+
+	for _, entry := range timeline(report) {
+		internal := ""
+		if entry.internal {
+			internal = " (internal)"
+		}
+		fmt.Fprintf(&b, "\n[%s] %s %s%s\n", entry.at.UTC().Format("2006-01-02 15:04 MST"), entry.actor, entry.action, internal)
+		if entry.message != "" {
+			for _, line := range strings.Split(strings.TrimSpace(entry.message), "\n") {
+				fmt.Fprintf(&b, "    %s\n", line)
+			}
+		}
+		for _, file := range entry.files {
+			fmt.Fprintf(&b, "    attachment: %s\n", file)
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// reportHeader returns the label and value of every header field that is set.
+func reportHeader(report *types.ReportDetail) [][2]string {
+	rel := report.Relationships
+
+	state := report.Attributes.State
+	if report.Attributes.Substate != "" {
+		state = fmt.Sprintf("%s (%s)", report.Attributes.Substate, report.Attributes.State)
+	}
+
+	fields := [][2]string{
+		{"Report", report.Id},
+		{"Program", rel.Program.Data.Attributes.Handle},
+		{"State", state},
+	}
+	if rel.Severity.Data != nil {
+		fields = append(fields, [2]string{"Severity", rel.Severity.Data.Attributes.Rating})
+	}
+	if rel.Weakness.Data != nil {
+		weakness := rel.Weakness.Data.Attributes.Name
+		if id := rel.Weakness.Data.Attributes.ExternalId; id != "" {
+			weakness = fmt.Sprintf("%s (%s)", weakness, strings.ToUpper(id))
+		}
+		fields = append(fields, [2]string{"Weakness", weakness})
+	}
+	if rel.StructuredScope.Data != nil {
+		fields = append(fields, [2]string{"Asset", rel.StructuredScope.Data.Attributes.AssetIdentifier})
+	}
+	if !report.Attributes.CreatedAt.IsZero() {
+		fields = append(fields, [2]string{"Submitted", report.Attributes.CreatedAt.UTC().Format("2006-01-02 15:04 MST")})
+	}
+	if total := bountyTotal(rel.Bounties.Data); total > 0 {
+		fields = append(fields, [2]string{"Bounty", formatAmount(total, bountyCurrency(report))})
+	}
+
+	return slices.DeleteFunc(fields, func(field [2]string) bool { return field[1] == "" })
+}
+
+// timeline returns the report's activities as entries sorted oldest first.
+func timeline(report *types.ReportDetail) []timelineEntry {
+	activities := slices.Clone(report.Relationships.Activities.Data)
+	slices.SortStableFunc(activities, func(a, b types.Activity) int {
+		return cmp.Compare(a.Attributes.CreatedAt.UnixNano(), b.Attributes.CreatedAt.UnixNano())
+	})
+
+	currency := bountyCurrency(report)
+	entries := make([]timelineEntry, 0, len(activities))
+	for _, activity := range activities {
+		entry := timelineEntry{
+			at:       activity.Attributes.CreatedAt,
+			actor:    actorName(activity.Relationships.Actor),
+			action:   describeActivity(&activity, currency),
+			message:  activity.Attributes.Message,
+			internal: activity.Attributes.Internal,
+		}
+		for _, attachment := range activity.Relationships.Attachments.Data {
+			entry.files = append(entry.files, attachment.Attributes.FileName)
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
+
+// describeActivity describes what happened in activity, bounty amounts are in currency.
+func describeActivity(activity *types.Activity, currency string) string {
+	description, ok := activityDescriptions[activity.Type]
+	if !ok {
+		description = strings.ReplaceAll(strings.TrimPrefix(activity.Type, "activity-"), "-", " ")
+	}
+
+	if activity.Type == "activity-bounty-awarded" {
+		description += " of " + formatAmount(activity.Attributes.BountyAmount.Float64(), currency)
+		if bonus := activity.Attributes.BonusAmount.Float64(); bonus > 0 {
+			description += " + " + formatAmount(bonus, currency) + " bonus"
+		}
+	}
+
+	return description
+}
+
+// bountyCurrency returns the currency the report's bounties are paid in, that of its first bounty or otherwise the
+// program's currency.
+func bountyCurrency(report *types.ReportDetail) string {
+	if bounties := report.Relationships.Bounties.Data; len(bounties) != 0 && bounties[0].Attributes.AwardedCurrency != "" {
+		return bounties[0].Attributes.AwardedCurrency
+	}
+	return report.Relationships.Program.Data.Attributes.Currency
+}
+
+func actorName(actor types.Actor) string {
+	attrs := actor.Data.Attributes
+	switch {
+	case attrs.Username != "":
+		return "@" + attrs.Username
+	case attrs.Handle != "":
+		return attrs.Handle
+	case attrs.Name != "":
+		return attrs.Name
+	default:
+		return "someone"
+	}
+}
+
+// formatAmount formats amount in currency, amounts without a currency are in types.DefaultCurrency.
+func formatAmount(amount float64, currency string) string {
+	if currency == "" || currency == types.DefaultCurrency {
+		return fmt.Sprintf("$%.2f", amount)
+	}
+	return fmt.Sprintf("%.2f %s", amount, currency)
+}
@@ -0,0 +1,137 @@
+package h1
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/ryanjarv/h1/pkg/types"
+)
+
+const timelineReport = `
+{
+  "id": "1337",
+  "attributes": {"title": "XSS in search", "state": "closed", "substate": "resolved", "created_at": "2024-01-01T10:00:00Z"},
+  "relationships": {
+    "program": {"data": {"attributes": {"handle": "security"}}},
+    "weakness": {"data": {"attributes": {"name": "Cross-site Scripting (XSS) - Reflected", "external_id": "cwe-79"}}},
+    "severity": {"data": {"attributes": {"rating": "medium"}}},
+    "structured_scope": {"data": {"attributes": {"asset_identifier": "www.example.com"}}},
+    "bounties": {"data": [{"attributes": {"awarded_amount": "500.00", "awarded_bonus_amount": "50.00", "awarded_currency": "USD"}}]},
+    "activities": {"data": [
+      {"type": "activity-bounty-awarded", "attributes": {"created_at": "2024-01-03T09:00:00Z", "bounty_amount": "500.00", "bonus_amount": "50.00"},
+       "relationships": {"actor": {"data": {"attributes": {"handle": "security"}}}}},
+      {"type": "activity-bug-triaged", "attributes": {"message": "Thanks, confirmed.\nWorking on a fix.", "created_at": "2024-01-02T12:30:00Z"},
+       "relationships": {"actor": {"data": {"attributes": {"username": "triager"}}}}},
+      {"type": "activity-comment", "attributes": {"message": "Updated PoC.", "created_at": "2024-01-02T14:00:00Z"},
+       "relationships": {"actor": {"data": {"attributes": {"username": "hacker"}}},
+                         "attachments": {"data": [{"attributes": {"file_name": "poc.png"}}]}}}
+    ]}
+  }
+}`
+
+func TestRenderMarkdown(t *testing.T) {
+	report := types.ReportDetail{}
+	if err := json.Unmarshal([]byte(timelineReport), &report); err != nil {
+		t.Fatal(err)
+	}
+
+	var got strings.Builder
+	if err := RenderMarkdown(&got, &report); err != nil {
+		t.Fatalf("RenderMarkdown() error = %v", err)
+	}
+
+	want := `# XSS in search
+
+- **Report:** 1337
+- **Program:** security
+- **State:** resolved (closed)
+- **Severity:** medium
+- **Weakness:** Cross-site Scripting (XSS) - Reflected (CWE-79)
+- **Asset:** www.example.com
+- **Submitted:** 2024-01-01 10:00 UTC
+- **Bounty:** $550.00
+
+## 2024-01-02 12:30 UTC — @triager changed the status to Triaged
+
+Thanks, confirmed.
+Working on a fix.
+
+## 2024-01-02 14:00 UTC — @hacker commented
+
+Updated PoC.
+
+Attachments:
+- poc.png
+
+## 2024-01-03 09:00 UTC — security awarded a bounty of $500.00 + $50.00 bonus
+`
+	if diff := cmp.Diff(want, got.String()); diff != "" {
+		t.Errorf("RenderMarkdown() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestRenderText(t *testing.T) {
+	report := types.ReportDetail{}
+	if err := json.Unmarshal([]byte(timelineReport), &report); err != nil {
+		t.Fatal(err)
+	}
+
+	var got strings.Builder
+	if err := RenderText(&got, &report); err != nil {
+		t.Fatalf("RenderText() error = %v", err)
+	}
+
+	want := `XSS in search
+=============
+
+Report: 1337
+Program: security
+State: resolved (closed)
+Severity: medium
+Weakness: Cross-site Scripting (XSS) - Reflected (CWE-79)
+Asset: www.example.com
+Submitted: 2024-01-01 10:00 UTC
+Bounty: $550.00
+
+[2024-01-02 12:30 UTC] @triager changed the status to Triaged
+    Thanks, confirmed.
+    Working on a fix.
+
+[2024-01-02 14:00 UTC] @hacker commented
+    Updated PoC.
+    attachment: poc.png
+
+[2024-01-03 09:00 UTC] security awarded a bounty of $500.00 + $50.00 bonus
+`
+	if diff := cmp.Diff(want, got.String()); diff != "" {
+		t.Errorf("RenderText() mismatch (-want +got):\n%s", diff)
+	}
+
+	// The underline matches the title's length in characters rather than bytes.
+	report.Attributes.Title = "XSS in Suche für Städte"
+	got.Reset()
+	if err := RenderText(&got, &report); err != nil {
+		t.Fatalf("RenderText() error = %v", err)
+	}
+	if !strings.HasPrefix(got.String(), report.Attributes.Title+"\n"+strings.Repeat("=", 23)+"\n") {
+		t.Errorf("RenderText() title = %q", strings.SplitN(got.String(), "\n", 3)[:2])
+	}
+
+	// Bounty awards are shown in the bounty's currency, falling back to the program's.
+	for _, tt := range []struct{ awarded, program, want string }{
+		{"EUR", "", "security awarded a bounty of 500.00 EUR + 50.00 EUR bonus"},
+		{"", "GBP", "security awarded a bounty of 500.00 GBP + 50.00 GBP bonus"},
+	} {
+		report.Relationships.Bounties.Data[0].Attributes.AwardedCurrency = tt.awarded
+		report.Relationships.Program.Data.Attributes.Currency = tt.program
+		got.Reset()
+		if err := RenderText(&got, &report); err != nil {
+			t.Fatalf("RenderText() error = %v", err)
+		}
+		if !strings.Contains(got.String(), tt.want) {
+			t.Errorf("RenderText() = %s, want it to contain %q", got.String(), tt.want)
+		}
+	}
+}
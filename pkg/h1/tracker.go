@@ -0,0 +1,273 @@
+package h1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ryanjarv/h1/pkg/types"
+)
+
+// DefaultTrackerInterval is how often a Tracker polls when TrackerInput.Interval is not set.
+const DefaultTrackerInterval = 10 * time.Minute
+
+type EventType string
+
+const (
+	EventTriaged       EventType = "triaged"
+	EventNeedsMoreInfo EventType = "needs-more-info"
+	EventResolved      EventType = "resolved"
+	EventStateChanged  EventType = "state-changed"
+	EventBountyAwarded EventType = "bounty-awarded"
+	EventNewComment    EventType = "new-comment"
+	EventNewReport     EventType = "new-report"
+)
+
+// Event is a change to one of the tracked reports between two polls. Reports the tracker hasn't seen before only get
+// an EventNewReport, whose Previous is the zero snapshot.
+type Event struct {
+	Type     EventType      `json:"type"`
+	ReportId string         `json:"report_id"`
+	Title    string         `json:"title"`
+	Program  string         `json:"program"`
+	Previous ReportSnapshot `json:"previous"`
+	Current  ReportSnapshot `json:"current"`
+}
+
+// ReportSnapshot is the state of a report the tracker compares between polls.
+type ReportSnapshot struct {
+	State          string     `json:"state"`
+	Substate       string     `json:"substate"`
+	Bounty         float64    `json:"bounty"`
+	Activities     int        `json:"activities"`
+	Comments       int        `json:"comments"`
+	LastActivityAt *time.Time `json:"last_activity_at,omitempty"`
+}
+
+// TrackerInput is the input parameters for NewTracker.
+//
+// StatePath must be provided, the last seen snapshot of every report is persisted there between polls and restarts.
+// Interval is optional and defaults to DefaultTrackerInterval.
+// Reports is optional and filters which reports are tracked.
+type TrackerInput struct {
+	StatePath string `json:"state_path"`
+
+	Interval time.Duration `json:"interval,omitempty"`
+
+	Reports *MyReportsInput `json:"reports,omitempty"`
+}
+
+// Tracker periodically lists the authenticated user's reports and emits an Event for every change.
+//
+// The first poll without a state file only records a baseline, otherwise every existing report would be reported as
+// changed.
+type Tracker struct {
+	h1    *Hackerone
+	input TrackerInput
+
+	mu        sync.Mutex
+	snapshots map[string]ReportSnapshot
+}
+
+func (h1 *Hackerone) NewTracker(input *TrackerInput) (*Tracker, error) {
+	if input.StatePath == "" {
+		return nil, errors.New("NewTracker: StatePath is required")
+	}
+
+	t := &Tracker{h1: h1, input: *input}
+	if t.input.Interval == 0 {
+		t.input.Interval = DefaultTrackerInterval
+	}
+	if t.input.Reports == nil {
+		t.input.Reports = &MyReportsInput{}
+	}
+
+	data, err := os.ReadFile(input.StatePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return t, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("NewTracker: reading %s: %w", input.StatePath, err)
+	}
+
+	if err := json.Unmarshal(data, &t.snapshots); err != nil {
+		return nil, fmt.Errorf("NewTracker: failed to unmarshal %s: %w", input.StatePath, err)
+	}
+
+	return t, nil
+}
+
+// Poll lists the reports once, persists their snapshots and returns the events since the previous poll.
+//
+// Report details are only fetched for reports that are new or whose last activity changed since the previous poll.
+func (t *Tracker) Poll() ([]Event, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	baseline := t.snapshots == nil
+	snapshots := map[string]ReportSnapshot{}
+
+	var events []Event
+	for report, err := range t.h1.MyReports(t.input.Reports) {
+		if err != nil {
+			return nil, fmt.Errorf("Poll: %w", err)
+		}
+
+		previous, seen := t.snapshots[report.Id]
+		current, err := t.snapshot(&report, previous, seen)
+		if err != nil {
+			return nil, fmt.Errorf("Poll: %w", err)
+		}
+		snapshots[report.Id] = current
+
+		if !baseline {
+			events = append(events, diffSnapshots(&report, previous, current, seen)...)
+		}
+	}
+
+	data, err := json.Marshal(snapshots)
+	if err != nil {
+		return nil, fmt.Errorf("Poll: failed to marshal state: %w", err)
+	}
+	if err := writeFileAtomic(t.input.StatePath, data); err != nil {
+		return nil, fmt.Errorf("Poll: saving state: %w", err)
+	}
+	t.snapshots = snapshots
+
+	return events, nil
+}
+
+// Run polls every interval until ctx is done, calling onEvent for every event. Failed polls are logged and retried
+// on the next interval.
+func (t *Tracker) Run(ctx context.Context, onEvent func(Event)) error {
+	ticker := time.NewTicker(t.input.Interval)
+	defer ticker.Stop()
+
+	for {
+		events, err := t.Poll()
+		if err != nil {
+			log.Printf("tracker: %s", err)
+		}
+		for _, event := range events {
+			onEvent(event)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Watch runs the tracker in the background and delivers events on the returned channel, which is closed once ctx is
+// done.
+func (t *Tracker) Watch(ctx context.Context) <-chan Event {
+	events := make(chan Event)
+
+	go func() {
+		defer close(events)
+		_ = t.Run(ctx, func(event Event) {
+			select {
+			case events <- event:
+			case <-ctx.Done():
+			}
+		})
+	}()
+
+	return events
+}
+
+// snapshot builds the current snapshot of report, reusing the previous activity counts when the API reports no
+// activity on it since.
+func (t *Tracker) snapshot(report *types.Report, previous ReportSnapshot, seen bool) (ReportSnapshot, error) {
+	current := ReportSnapshot{
+		State:          report.Attributes.State,
+		Substate:       report.Attributes.Substate,
+		Bounty:         bountyTotal(report.Relationships.Bounties.Data),
+		LastActivityAt: report.Attributes.LastActivityAt,
+	}
+
+	if seen && current.LastActivityAt != nil && sameTime(previous.LastActivityAt, current.LastActivityAt) {
+		current.Activities, current.Comments = previous.Activities, previous.Comments
+		return current, nil
+	}
+
+	detail, err := t.h1.Report(report.Id).GetDetail()
+	if err != nil {
+		return current, fmt.Errorf("report %s: %w", report.Id, err)
+	}
+
+	for _, activity := range detail.Relationships.Activities.Data {
+		current.Activities++
+		if activity.Type == "activity-comment" {
+			current.Comments++
+		}
+	}
+	if bounty := bountyTotal(detail.Relationships.Bounties.Data); bounty > current.Bounty {
+		current.Bounty = bounty
+	}
+
+	return current, nil
+}
+
+// diffSnapshots returns the events between two snapshots of report, or a single EventNewReport when the report wasn't
+// seen before since there's nothing to compare it with.
+func diffSnapshots(report *types.Report, previous, current ReportSnapshot, seen bool) []Event {
+	event := func(eventType EventType) Event {
+		return Event{
+			Type:     eventType,
+			ReportId: report.Id,
+			Title:    report.Attributes.Title,
+			Program:  report.Relationships.Program.Data.Attributes.Handle,
+			Previous: previous,
+			Current:  current,
+		}
+	}
+
+	if !seen {
+		return []Event{event(EventNewReport)}
+	}
+
+	var events []Event
+	if current.Substate != previous.Substate {
+		switch current.Substate {
+		case "triaged":
+			events = append(events, event(EventTriaged))
+		case "needs-more-info":
+			events = append(events, event(EventNeedsMoreInfo))
+		case "resolved":
+			events = append(events, event(EventResolved))
+		default:
+			events = append(events, event(EventStateChanged))
+		}
+	}
+	if current.Bounty > previous.Bounty {
+		events = append(events, event(EventBountyAwarded))
+	}
+	if current.Comments > previous.Comments {
+		events = append(events, event(EventNewComment))
+	}
+
+	return events
+}
+
+// bountyTotal sums the awarded amounts and bonuses of bounties.
+func bountyTotal(bounties []types.Bounty) float64 {
+	total := 0.0
+	for _, bounty := range bounties {
+		total += bounty.Attributes.AwardedAmount.Float64() + bounty.Attributes.AwardedBonusAmount.Float64()
+	}
+	return total
+}
+
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
@@ -0,0 +1,144 @@
+package h1
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestTracker_Poll(t *testing.T) {
+	// Each poll serves the listing and report detail from these, keyed by request path.
+	var responses map[string]string
+	details := 0
+	client := clientFunc(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Path != "/v1/hackers/me/reports" {
+			details++
+		}
+		body, ok := responses[req.URL.Path]
+		if !ok {
+			t.Fatalf("unexpected request to %s", req.URL.Path)
+		}
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(body)))}, nil
+	})
+	h1 := &Hackerone{token: "token", username: "username", client: client}
+
+	tracker, err := h1.NewTracker(&TrackerInput{StatePath: filepath.Join(t.TempDir(), "state.json")})
+	if err != nil {
+		t.Fatalf("NewTracker() error = %v", err)
+	}
+
+	poll := func() []EventType {
+		t.Helper()
+		events, err := tracker.Poll()
+		if err != nil {
+			t.Fatalf("Poll() error = %v", err)
+		}
+		var types []EventType
+		for _, event := range events {
+			if event.ReportId != "1" || event.Program != "security" {
+				t.Errorf("Poll() event %+v is not for report 1 of security", event)
+			}
+			types = append(types, event.Type)
+		}
+		return types
+	}
+
+	report := func(substate, lastActivity, bounties string) string {
+		return `{"data": [{"id": "1", "attributes": {"title": "XSS", "state": "open", "substate": "` + substate + `", "last_activity_at": "` + lastActivity + `"},
+  "relationships": {"program": {"data": {"attributes": {"handle": "security"}}}, "bounties": {"data": [` + bounties + `]}}}]}`
+	}
+	detail := func(activities ...string) string {
+		var data []string
+		for _, activity := range activities {
+			data = append(data, `{"type": "`+activity+`"}`)
+		}
+		return `{"id": "1", "relationships": {"activities": {"data": [` + strings.Join(data, ",") + `]}}}`
+	}
+
+	// The first poll only records a baseline.
+	responses = map[string]string{
+		"/v1/hackers/me/reports": report("new", "2024-01-01T00:00:00Z", ""),
+		"/v1/hackers/reports/1":  detail(),
+	}
+	if got := poll(); len(got) != 0 {
+		t.Errorf("Poll() baseline events = %v, want none", got)
+	}
+
+	// Nothing changed, the detail isn't fetched again.
+	if got := poll(); len(got) != 0 {
+		t.Errorf("Poll() events = %v, want none", got)
+	}
+	if details != 1 {
+		t.Errorf("report detail fetched %d times, want 1", details)
+	}
+
+	responses = map[string]string{
+		"/v1/hackers/me/reports": report("triaged", "2024-01-02T00:00:00Z", ""),
+		"/v1/hackers/reports/1":  detail("activity-bug-triaged", "activity-comment"),
+	}
+	if diff := cmp.Diff([]EventType{EventTriaged, EventNewComment}, poll()); diff != "" {
+		t.Errorf("Poll() events mismatch (-want +got):\n%s", diff)
+	}
+
+	responses = map[string]string{
+		"/v1/hackers/me/reports": report("resolved", "2024-01-03T00:00:00Z", `{"attributes": {"awarded_amount": "500.00"}}`),
+		"/v1/hackers/reports/1":  detail("activity-bug-triaged", "activity-comment", "activity-bounty-awarded", "activity-bug-resolved"),
+	}
+	if diff := cmp.Diff([]EventType{EventResolved, EventBountyAwarded}, poll()); diff != "" {
+		t.Errorf("Poll() events mismatch (-want +got):\n%s", diff)
+	}
+
+	// A restarted tracker picks up from the persisted state.
+	tracker, err = h1.NewTracker(&TrackerInput{StatePath: tracker.input.StatePath})
+	if err != nil {
+		t.Fatalf("NewTracker() error = %v", err)
+	}
+	if got := poll(); len(got) != 0 {
+		t.Errorf("Poll() events after restart = %v, want none", got)
+	}
+}
+
+func TestTracker_Poll_NewReport(t *testing.T) {
+	listing := `{"data": [{"id": "1", "attributes": {"substate": "new", "last_activity_at": "2024-01-01T00:00:00Z"}}]}`
+	client := clientFunc(func(req *http.Request) (*http.Response, error) {
+		body := `{"id": "1", "relationships": {"activities": {"data": []}}}`
+		switch req.URL.Path {
+		case "/v1/hackers/me/reports":
+			body = listing
+		case "/v1/hackers/reports/2":
+			body = `{"id": "2", "relationships": {"activities": {"data": [{"type": "activity-comment"}]}}}`
+		}
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(body)))}, nil
+	})
+	h1 := &Hackerone{token: "token", username: "username", client: client}
+
+	tracker, err := h1.NewTracker(&TrackerInput{StatePath: filepath.Join(t.TempDir(), "state.json")})
+	if err != nil {
+		t.Fatalf("NewTracker() error = %v", err)
+	}
+	if _, err := tracker.Poll(); err != nil {
+		t.Fatalf("Poll() error = %v", err)
+	}
+
+	// A report submitted after the baseline already has a comment and a bounty, it is only reported as new.
+	listing = `{"data": [
+  {"id": "1", "attributes": {"substate": "new", "last_activity_at": "2024-01-01T00:00:00Z"}},
+  {"id": "2", "attributes": {"substate": "new", "last_activity_at": "2024-01-02T00:00:00Z"},
+   "relationships": {"bounties": {"data": [{"attributes": {"awarded_amount": "100.00"}}]}}}]}`
+	events, err := tracker.Poll()
+	if err != nil {
+		t.Fatalf("Poll() error = %v", err)
+	}
+	var got []string
+	for _, event := range events {
+		got = append(got, event.ReportId+" "+string(event.Type))
+	}
+	if diff := cmp.Diff([]string{"2 new-report"}, got); diff != "" {
+		t.Errorf("Poll() events mismatch (-want +got):\n%s", diff)
+	}
+}
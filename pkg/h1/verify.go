@@ -0,0 +1,138 @@
+package h1
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+)
+
+var (
+	// ErrBadUsername is returned by Verify when the username doesn't belong to any HackerOne account.
+	ErrBadUsername = errors.New("unknown HackerOne username")
+
+	// ErrBadToken is returned by Verify when the username exists but the API token was rejected, e.g. because it
+	// expired, was revoked or belongs to another account.
+	ErrBadToken = errors.New("HackerOne API token rejected")
+
+	// ErrUnauthorized is returned by Verify when the credentials were rejected but it couldn't be determined which
+	// one is wrong.
+	ErrUnauthorized = errors.New("HackerOne credentials rejected")
+
+	// ErrNetwork is returned by Verify when the API couldn't be reached at all.
+	ErrNetwork = errors.New("HackerOne API unreachable")
+)
+
+// AccountInfo describes the account the client is authenticated as.
+//
+// Programs is the number of programs accessible to the account, it is nil when the API doesn't provide a total, which
+// is usually the case for the hacker program listing. Use CountPrograms to count them.
+type AccountInfo struct {
+	Username string `json:"username"`
+
+	Programs *int `json:"programs,omitempty"`
+}
+
+// Verify makes a single authenticated call to check the client's credentials, so CLIs and daemons can fail fast at
+// startup rather than deep into a sync. Errors wrap one of ErrBadUsername, ErrBadToken, ErrUnauthorized or
+// ErrNetwork where it applies.
+//
+// When the API rejects the credentials, the username is looked up on its public profile to tell whether the
+// username or the token is wrong.
+func (h1 *Hackerone) Verify() (*AccountInfo, error) {
+	if h1.username == "" {
+		return nil, fmt.Errorf("Verify: %w: no username configured", ErrBadUsername)
+	} else if h1.token == "" {
+		return nil, fmt.Errorf("Verify: %w: no token configured", ErrBadToken)
+	}
+
+	programs, err := h1.countPrograms()
+	if err == nil {
+		return &AccountInfo{Username: h1.username, Programs: programs}, nil
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return nil, fmt.Errorf("Verify: %w: %w", ErrNetwork, err)
+	} else if statusCode(err) != http.StatusUnauthorized {
+		return nil, fmt.Errorf("Verify: %w", err)
+	}
+
+	exists, profileErr := h1.profileExists()
+	switch {
+	case profileErr != nil:
+		return nil, fmt.Errorf("Verify: %w: checking profile of %s: %w", ErrUnauthorized, h1.username, profileErr)
+	case !exists:
+		return nil, fmt.Errorf("Verify: %w: %s", ErrBadUsername, h1.username)
+	default:
+		return nil, fmt.Errorf("Verify: %w for %s", ErrBadToken, h1.username)
+	}
+}
+
+// countPrograms fetches a single page of the program listing and returns its total_count, which is nil when the API
+// doesn't provide it.
+func (h1 *Hackerone) countPrograms() (*int, error) {
+	doc, err := h1.GetDocument("https://api.hackerone.com/v1/hackers/programs?page%5Bsize%5D=1")
+	if err != nil {
+		return nil, err
+	}
+
+	meta := struct {
+		TotalCount *int `json:"total_count"`
+	}{}
+	if len(doc.Meta) != 0 {
+		if err := json.Unmarshal(doc.Meta, &meta); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal meta: %w", err)
+		}
+	}
+
+	return meta.TotalCount, nil
+}
+
+// CountPrograms returns the number of programs accessible to the account. The listing's total is used when the API
+// provides one, otherwise every page of the listing is walked, which takes one request per 100 programs.
+func (h1 *Hackerone) CountPrograms() (int, error) {
+	count := 0
+	for page, err := range Pages[json.RawMessage](h1, &PaginateInput{
+		Uri:       "https://api.hackerone.com/v1/hackers/programs",
+		ListInput: ListInput{PageSize: 100},
+	}) {
+		if err != nil {
+			return 0, fmt.Errorf("CountPrograms: %w", err)
+		} else if page.Total != nil {
+			return *page.Total, nil
+		}
+		count += page.Count
+	}
+	return count, nil
+}
+
+// profileExists checks whether the username has a public HackerOne profile. The request is made without
+// credentials since they were just rejected.
+func (h1 *Hackerone) profileExists() (bool, error) {
+	req, err := http.NewRequest("GET", "https://hackerone.com/"+url.PathEscape(h1.username), nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	h1.limiter.Wait()
+	resp, err := h1.client.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return false, nil
+	case resp.StatusCode >= 200 && resp.StatusCode <= 299:
+		return true, nil
+	default:
+		return false, &StatusError{Uri: req.URL.String(), StatusCode: resp.StatusCode, Status: resp.Status}
+	}
+}
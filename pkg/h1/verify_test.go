@@ -0,0 +1,163 @@
+package h1
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestHackerone_Verify(t *testing.T) {
+	response := func(status int, body string) *http.Response {
+		return &http.Response{StatusCode: status, Status: http.StatusText(status), Body: io.NopCloser(bytes.NewReader([]byte(body)))}
+	}
+
+	programs := 42
+	tests := []struct {
+		name         string
+		token        string
+		responses    []*http.Response
+		errors       []error
+		want         *AccountInfo
+		wantErr      error
+		wantLastHost string
+	}{
+		{
+			name:      "program count from total_count",
+			token:     "token",
+			responses: []*http.Response{response(200, `{"data": [{"id": "1"}], "meta": {"total_count": 42}}`)},
+			want:      &AccountInfo{Username: "username", Programs: &programs},
+		},
+		{
+			name:  "no program count without total_count",
+			token: "token",
+			responses: []*http.Response{
+				response(200, `{"data": [{"id": "1"}], "links": {"next": "https://api.hackerone.com/v1/hackers/programs?page%5Bnumber%5D=2"}}`),
+			},
+			want: &AccountInfo{Username: "username"},
+		},
+		{
+			name:         "unknown username",
+			token:        "token",
+			responses:    []*http.Response{response(401, ``), response(404, ``)},
+			wantErr:      ErrBadUsername,
+			wantLastHost: "hackerone.com",
+		},
+		{
+			name:         "rejected token",
+			token:        "token",
+			responses:    []*http.Response{response(401, ``), response(200, `{}`)},
+			wantErr:      ErrBadToken,
+			wantLastHost: "hackerone.com",
+		},
+		{
+			name:         "profile lookup fails",
+			token:        "token",
+			responses:    []*http.Response{response(401, ``), response(503, ``)},
+			wantErr:      ErrUnauthorized,
+			wantLastHost: "hackerone.com",
+		},
+		{
+			name:    "network failure",
+			token:   "token",
+			errors:  []error{&url.Error{Op: "Get", URL: "https://api.hackerone.com", Err: syscall.ECONNREFUSED}},
+			wantErr: ErrNetwork,
+		},
+		{
+			name:  "connection reset",
+			token: "token",
+			errors: []error{
+				&net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET},
+				&net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET},
+				&net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET},
+			},
+			wantErr: ErrNetwork,
+		},
+		{
+			name:    "missing token",
+			wantErr: ErrBadToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockClient{DoResponse: tt.responses, DoErrors: tt.errors}
+			h1 := &Hackerone{token: tt.token, username: "username", client: mockClient}
+
+			got, err := h1.Verify()
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Verify() error = %v, want %v", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("Verify() error = %v", err)
+			} else if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Verify() mismatch (-want +got):\n%s", diff)
+			} else if len(mockClient.Calls) != 1 {
+				t.Errorf("Verify() sent %d requests, want 1", len(mockClient.Calls))
+			}
+
+			if tt.wantLastHost != "" {
+				last := mockClient.Calls[len(mockClient.Calls)-1]
+				if last.URL.Host != tt.wantLastHost || last.Header.Get("Authorization") != "" {
+					t.Errorf("profile lookup sent to %s with authorization %q", last.URL.Host, last.Header.Get("Authorization"))
+				}
+			}
+		})
+	}
+}
+
+func TestHackerone_CountPrograms(t *testing.T) {
+	response := func(body string) *http.Response {
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(body)))}
+	}
+
+	tests := []struct {
+		name      string
+		responses []*http.Response
+		want      int
+		wantCalls int
+	}{
+		{
+			name:      "total_count",
+			responses: []*http.Response{response(`{"data": [{"id": "1"}], "meta": {"total_count": 42}}`)},
+			want:      42,
+			wantCalls: 1,
+		},
+		{
+			name: "walks every page without total_count",
+			responses: []*http.Response{
+				response(`{"data": [{"id": "1"}, {"id": "2"}], "links": {"next": "https://api.hackerone.com/v1/hackers/programs?page%5Bnumber%5D=2&page%5Bsize%5D=100"}}`),
+				response(`{"data": [{"id": "3"}], "links": {}}`),
+			},
+			want:      3,
+			wantCalls: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockClient{DoResponse: tt.responses}
+			h1 := &Hackerone{token: "token", username: "username", client: mockClient}
+
+			got, err := h1.CountPrograms()
+			if err != nil {
+				t.Fatalf("CountPrograms() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("CountPrograms() = %d, want %d", got, tt.want)
+			}
+			if len(mockClient.Calls) != tt.wantCalls {
+				t.Errorf("CountPrograms() sent %d requests, want %d", len(mockClient.Calls), tt.wantCalls)
+			} else if size := mockClient.Calls[0].URL.Query().Get("page[size]"); size != "100" {
+				t.Errorf("CountPrograms() page size = %q, want 100", size)
+			}
+		})
+	}
+}
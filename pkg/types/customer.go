@@ -0,0 +1,35 @@
+package types
+
+import "time"
+
+// CustomerReport is a report as seen by the program it was submitted to through the customer API.
+type CustomerReport struct {
+	Id            string                      `json:"id"`
+	Type          string                      `json:"type"`
+	Attributes    CustomerReportAttributes    `json:"attributes"`
+	Relationships CustomerReportRelationships `json:"relationships"`
+}
+
+// CustomerReportAttributes are the attributes of a report in the customer API, unlike the hacker API State is the
+// triage state itself such as new, triaged, needs-more-info or resolved.
+type CustomerReportAttributes struct {
+	Title                    string     `json:"title"`
+	State                    string     `json:"state"`
+	VulnerabilityInformation string     `json:"vulnerability_information,omitempty"`
+	Impact                   string     `json:"impact,omitempty"`
+	IssueTrackerReferenceId  string     `json:"issue_tracker_reference_id,omitempty"`
+	CreatedAt                time.Time  `json:"created_at"`
+	TriagedAt                *time.Time `json:"triaged_at,omitempty"`
+	ClosedAt                 *time.Time `json:"closed_at,omitempty"`
+	DisclosedAt              *time.Time `json:"disclosed_at,omitempty"`
+	BountyAwardedAt          *time.Time `json:"bounty_awarded_at,omitempty"`
+	LastActivityAt           *time.Time `json:"last_activity_at,omitempty"`
+}
+
+// CustomerReportRelationships adds the assignee to the relationships shared with the hacker API, the assignee is
+// either a user or a group.
+type CustomerReportRelationships struct {
+	ReportDetailRelationships
+
+	Assignee Actor `json:"assignee"`
+}
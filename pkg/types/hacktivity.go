@@ -0,0 +1,33 @@
+package types
+
+import "time"
+
+// HacktivityItem is a publicly visible report on hacktivity, most attributes are only set once it is disclosed.
+type HacktivityItem struct {
+	Id            string                      `json:"id"`
+	Type          string                      `json:"type"`
+	Attributes    HacktivityItemAttributes    `json:"attributes"`
+	Relationships HacktivityItemRelationships `json:"relationships"`
+}
+
+type HacktivityItemAttributes struct {
+	Title                       string     `json:"title"`
+	Substate                    string     `json:"substate"`
+	Url                         string     `json:"url"`
+	Disclosed                   bool       `json:"disclosed"`
+	DisclosedAt                 *time.Time `json:"disclosed_at,omitempty"`
+	SubmittedAt                 *time.Time `json:"submitted_at,omitempty"`
+	VulnerabilityInformation    string     `json:"vulnerability_information,omitempty"`
+	CveIds                      []string   `json:"cve_ids,omitempty"`
+	Cwe                         string     `json:"cwe,omitempty"`
+	SeverityRating              string     `json:"severity_rating,omitempty"`
+	Votes                       int        `json:"votes"`
+	TotalAwardedAmount          *float64   `json:"total_awarded_amount,omitempty"`
+	LatestDisclosableAction     string     `json:"latest_disclosable_action,omitempty"`
+	LatestDisclosableActivityAt *time.Time `json:"latest_disclosable_activity_at,omitempty"`
+}
+
+type HacktivityItemRelationships struct {
+	Reporter Actor         `json:"reporter"`
+	Program  ReportProgram `json:"program"`
+}
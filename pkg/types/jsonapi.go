@@ -0,0 +1,137 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// Document is a generic JSON:API document, for responses that don't have a hand-written type.
+//
+// Data holds the primary resources, Included the resources they reference. Use Resolve and Related to follow
+// relationships and Attributes to decode a resource's attributes into a typed struct.
+type Document struct {
+	Data     ResourceData    `json:"data"`
+	Included []Resource      `json:"included,omitempty"`
+	Links    Links           `json:"links,omitzero"`
+	Meta     json.RawMessage `json:"meta,omitempty"`
+}
+
+// ResourceData is the data member of a document or relationship, which is a single resource, null, or an array of
+// resources. Many reports whether it was an array so it is written back out the same way.
+type ResourceData struct {
+	Resources []Resource
+	Many      bool
+}
+
+// ResourceIdentifier identifies a resource by its type and id.
+type ResourceIdentifier struct {
+	Type string `json:"type"`
+	Id   string `json:"id"`
+}
+
+// Resource is a JSON:API resource object. The resources referenced by relationships are often only identifiers, but
+// the HackerOne API also embeds their attributes, both are represented as a Resource.
+type Resource struct {
+	Id            string                  `json:"id"`
+	Type          string                  `json:"type"`
+	Attributes    json.RawMessage         `json:"attributes,omitempty"`
+	Relationships map[string]Relationship `json:"relationships,omitempty"`
+	Links         Links                   `json:"links,omitzero"`
+	Meta          json.RawMessage         `json:"meta,omitempty"`
+}
+
+// Relationship is a named relationship of a resource.
+type Relationship struct {
+	Data  ResourceData    `json:"data"`
+	Links Links           `json:"links,omitzero"`
+	Meta  json.RawMessage `json:"meta,omitempty"`
+}
+
+func (d *ResourceData) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	switch {
+	case bytes.Equal(data, []byte("null")):
+		d.Resources, d.Many = nil, false
+	case len(data) > 0 && data[0] == '[':
+		d.Many = true
+		return json.Unmarshal(data, &d.Resources)
+	default:
+		resource := Resource{}
+		if err := json.Unmarshal(data, &resource); err != nil {
+			return err
+		}
+		d.Resources, d.Many = []Resource{resource}, false
+	}
+	return nil
+}
+
+func (d ResourceData) MarshalJSON() ([]byte, error) {
+	switch {
+	case d.Many:
+		if d.Resources == nil {
+			return []byte("[]"), nil
+		}
+		return json.Marshal(d.Resources)
+	case len(d.Resources) == 0:
+		return []byte("null"), nil
+	default:
+		return json.Marshal(d.Resources[0])
+	}
+}
+
+// One returns the single resource of to-one data, or nil if it is null.
+func (d ResourceData) One() *Resource {
+	if len(d.Resources) == 0 {
+		return nil
+	}
+	return &d.Resources[0]
+}
+
+func (r *Resource) Identifier() ResourceIdentifier {
+	return ResourceIdentifier{Type: r.Type, Id: r.Id}
+}
+
+// Resolve returns the resource identified by id from the document's included or primary resources.
+func (d *Document) Resolve(id ResourceIdentifier) (*Resource, bool) {
+	for _, resources := range [][]Resource{d.Included, d.Data.Resources} {
+		for i := range resources {
+			if resources[i].Type == id.Type && resources[i].Id == id.Id {
+				return &resources[i], true
+			}
+		}
+	}
+	return nil, false
+}
+
+// Related returns the resources of the relationship name of r. Identifiers are resolved against the document,
+// resources that aren't part of it are returned as they appear in the relationship.
+func (d *Document) Related(r *Resource, name string) []*Resource {
+	relationship, ok := r.Relationships[name]
+	if !ok {
+		return nil
+	}
+
+	related := make([]*Resource, 0, len(relationship.Data.Resources))
+	for i := range relationship.Data.Resources {
+		resource := &relationship.Data.Resources[i]
+		if resolved, ok := d.Resolve(resource.Identifier()); ok {
+			resource = resolved
+		}
+		related = append(related, resource)
+	}
+
+	return related
+}
+
+// Attributes decodes the attributes of r into T, e.g. Attributes[WeaknessAttributes](r).
+func Attributes[T any](r *Resource) (T, error) {
+	var attributes T
+	if len(r.Attributes) == 0 {
+		return attributes, nil
+	}
+	if err := json.Unmarshal(r.Attributes, &attributes); err != nil {
+		return attributes, fmt.Errorf("%s %s: failed to unmarshal attributes: %w", r.Type, r.Id, err)
+	}
+	return attributes, nil
+}
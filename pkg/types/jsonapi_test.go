@@ -0,0 +1,81 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDocument(t *testing.T) {
+	data := `{
+  "data": [
+    {"id": "1337", "type": "report", "attributes": {"title": "XSS in search"},
+     "relationships": {
+       "weakness": {"data": {"id": "60", "type": "weakness"}},
+       "program": {"data": {"id": "7", "type": "program", "attributes": {"handle": "security"}}},
+       "bounties": {"data": []},
+       "severity": {"data": null}
+     }}
+  ],
+  "included": [
+    {"id": "60", "type": "weakness", "attributes": {"name": "Cross-site Scripting (XSS) - Reflected", "external_id": "cwe-79"}}
+  ],
+  "links": {"next": "https://api.hackerone.com/v1/hackers/me/reports?page%5Bnumber%5D=2"}
+}`
+
+	doc := Document{}
+	if err := json.Unmarshal([]byte(data), &doc); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if !doc.Data.Many || len(doc.Data.Resources) != 1 || doc.Links.Next == "" {
+		t.Fatalf("Unmarshal() = %+v", doc)
+	}
+	report := &doc.Data.Resources[0]
+
+	// Identifiers resolve to the included resource, embedded resources are returned as they are.
+	weakness := doc.Related(report, "weakness")
+	if len(weakness) != 1 {
+		t.Fatalf("Related(weakness) = %v", weakness)
+	}
+	attrs, err := Attributes[WeaknessAttributes](weakness[0])
+	if err != nil {
+		t.Fatalf("Attributes() error = %v", err)
+	}
+	if attrs.ExternalId != "cwe-79" {
+		t.Errorf("Attributes() = %+v, want the included weakness", attrs)
+	}
+
+	program := doc.Related(report, "program")
+	if len(program) != 1 || program[0].Id != "7" {
+		t.Fatalf("Related(program) = %v", program)
+	}
+	if got, _ := Attributes[ProgramAttributes](program[0]); got.Handle != "security" {
+		t.Errorf("Attributes() handle = %q, want security", got.Handle)
+	}
+
+	if got := doc.Related(report, "bounties"); len(got) != 0 {
+		t.Errorf("Related(bounties) = %v, want none", got)
+	}
+	if got := report.Relationships["severity"].Data.One(); got != nil {
+		t.Errorf("severity One() = %v, want nil", got)
+	}
+
+	// Documents round trip with to-one, to-many and null data kept apart.
+	out, err := json.Marshal(&doc)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	roundTrip := Document{}
+	if err := json.Unmarshal(out, &roundTrip); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	normalize := cmp.Transformer("raw", func(r json.RawMessage) any {
+		var v any
+		_ = json.Unmarshal(r, &v)
+		return v
+	})
+	if diff := cmp.Diff(doc, roundTrip, normalize); diff != "" {
+		t.Errorf("round trip mismatch (-want +got):\n%s", diff)
+	}
+}
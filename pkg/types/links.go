@@ -0,0 +1,10 @@
+package types
+
+// Links are the pagination links of a JSON:API listing, any of them may be empty.
+type Links struct {
+	Self  string `json:"self,omitempty"`
+	First string `json:"first,omitempty"`
+	Prev  string `json:"prev,omitempty"`
+	Next  string `json:"next,omitempty"`
+	Last  string `json:"last,omitempty"`
+}
@@ -0,0 +1,43 @@
+package types
+
+import "time"
+
+// DefaultCurrency is the currency of amounts the payments endpoints return without one, HackerOne pays out in USD.
+const DefaultCurrency = "USD"
+
+type Balance struct {
+	Balance  float64 `json:"balance"`
+	Currency string  `json:"currency,omitempty"`
+}
+
+// Earning is a single payment credited to the hacker, Type is the kind of earning such as earning-bounty-earned or
+// earning-retest-completed.
+type Earning struct {
+	Id            string               `json:"id"`
+	Type          string               `json:"type"`
+	Attributes    EarningAttributes    `json:"attributes"`
+	Relationships EarningRelationships `json:"relationships"`
+}
+
+type EarningAttributes struct {
+	Amount    float64   `json:"amount"`
+	Currency  string    `json:"currency,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type EarningRelationships struct {
+	Program ReportProgram `json:"program"`
+	Bounty  struct {
+		Data *Bounty `json:"data,omitempty"`
+	} `json:"bounty"`
+}
+
+// Payout is a transfer of the hacker's balance to a payout provider, Status is one of sent, pending or failed.
+type Payout struct {
+	Amount         float64   `json:"amount"`
+	Currency       string    `json:"currency,omitempty"`
+	PaidOutAt      time.Time `json:"paid_out_at"`
+	Reference      string    `json:"reference"`
+	PayoutProvider string    `json:"payout_provider"`
+	Status         string    `json:"status"`
+}
@@ -0,0 +1,177 @@
+package types
+
+import (
+	"strconv"
+	"time"
+)
+
+// Amount is a monetary amount as returned by the API, which encodes them as decimal strings.
+type Amount string
+
+// Float64 returns the amount as a float, an empty or malformed amount is zero.
+func (a Amount) Float64() float64 {
+	f, err := strconv.ParseFloat(string(a), 64)
+	if err != nil {
+		return 0
+	}
+	return f
+}
+
+type Report struct {
+	Id            string              `json:"id"`
+	Type          string              `json:"type"`
+	Attributes    ReportAttributes    `json:"attributes"`
+	Relationships ReportRelationships `json:"relationships"`
+}
+
+// ReportAttributes are the attributes of a report, State is either open or closed and Substate is the triage state
+// such as new, triaged, needs-more-info or resolved.
+type ReportAttributes struct {
+	Title                    string     `json:"title"`
+	State                    string     `json:"state"`
+	Substate                 string     `json:"substate"`
+	VulnerabilityInformation string     `json:"vulnerability_information,omitempty"`
+	CreatedAt                time.Time  `json:"created_at"`
+	TriagedAt                *time.Time `json:"triaged_at,omitempty"`
+	ClosedAt                 *time.Time `json:"closed_at,omitempty"`
+	DisclosedAt              *time.Time `json:"disclosed_at,omitempty"`
+	BountyAwardedAt          *time.Time `json:"bounty_awarded_at,omitempty"`
+	LastActivityAt           *time.Time `json:"last_activity_at,omitempty"`
+	CveIds                   []string   `json:"cve_ids,omitempty"`
+}
+
+type ReportRelationships struct {
+	Program  ReportProgram  `json:"program"`
+	Weakness ReportWeakness `json:"weakness"`
+	Severity ReportSeverity `json:"severity"`
+	Bounties Bounties       `json:"bounties"`
+}
+
+type ReportProgram struct {
+	Data struct {
+		Id         string            `json:"id"`
+		Type       string            `json:"type"`
+		Attributes ProgramAttributes `json:"attributes"`
+	} `json:"data"`
+}
+
+type ReportWeakness struct {
+	Data *Weakness `json:"data,omitempty"`
+}
+
+type ReportSeverity struct {
+	Data *Severity `json:"data,omitempty"`
+}
+
+type Severity struct {
+	Id         string             `json:"id"`
+	Type       string             `json:"type"`
+	Attributes SeverityAttributes `json:"attributes"`
+}
+
+// SeverityAttributes is the severity of a report, Rating is one of none, low, medium, high or critical.
+type SeverityAttributes struct {
+	Rating     string    `json:"rating"`
+	Score      *float64  `json:"score,omitempty"`
+	AuthorType string    `json:"author_type,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type Bounties struct {
+	Data []Bounty `json:"data"`
+}
+
+type Bounty struct {
+	Id         string           `json:"id"`
+	Type       string           `json:"type"`
+	Attributes BountyAttributes `json:"attributes"`
+}
+
+type BountyAttributes struct {
+	Amount             Amount    `json:"amount"`
+	BonusAmount        Amount    `json:"bonus_amount"`
+	AwardedAmount      Amount    `json:"awarded_amount"`
+	AwardedBonusAmount Amount    `json:"awarded_bonus_amount"`
+	AwardedCurrency    string    `json:"awarded_currency"`
+	CreatedAt          time.Time `json:"created_at"`
+}
+
+type ReportDetail struct {
+	Id            string                    `json:"id"`
+	Type          string                    `json:"type"`
+	Attributes    ReportAttributes          `json:"attributes"`
+	Relationships ReportDetailRelationships `json:"relationships"`
+}
+
+type ReportDetailRelationships struct {
+	ReportRelationships
+
+	Reporter        Actor                 `json:"reporter"`
+	StructuredScope ReportStructuredScope `json:"structured_scope"`
+	Activities      Activities            `json:"activities"`
+	Attachments     Attachments           `json:"attachments"`
+}
+
+type ReportStructuredScope struct {
+	Data *ScopeData `json:"data,omitempty"`
+}
+
+type Activities struct {
+	Data []Activity `json:"data"`
+}
+
+// Activity is a single entry of a report's timeline, Type is the kind of activity such as activity-comment,
+// activity-bug-triaged or activity-bounty-awarded.
+type Activity struct {
+	Id            string                `json:"id"`
+	Type          string                `json:"type"`
+	Attributes    ActivityAttributes    `json:"attributes"`
+	Relationships ActivityRelationships `json:"relationships"`
+}
+
+type ActivityAttributes struct {
+	Message      string    `json:"message"`
+	Internal     bool      `json:"internal"`
+	BountyAmount Amount    `json:"bounty_amount,omitempty"`
+	BonusAmount  Amount    `json:"bonus_amount,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+type ActivityRelationships struct {
+	Actor       Actor       `json:"actor"`
+	Attachments Attachments `json:"attachments"`
+}
+
+// Actor is the user or program behind an activity, users have a Username and programs a Handle.
+type Actor struct {
+	Data struct {
+		Id         string          `json:"id"`
+		Type       string          `json:"type"`
+		Attributes ActorAttributes `json:"attributes"`
+	} `json:"data"`
+}
+
+type ActorAttributes struct {
+	Username string `json:"username,omitempty"`
+	Handle   string `json:"handle,omitempty"`
+	Name     string `json:"name,omitempty"`
+}
+
+type Attachments struct {
+	Data []Attachment `json:"data"`
+}
+
+type Attachment struct {
+	Id         string               `json:"id"`
+	Type       string               `json:"type"`
+	Attributes AttachmentAttributes `json:"attributes"`
+}
+
+type AttachmentAttributes struct {
+	FileName    string    `json:"file_name"`
+	ContentType string    `json:"content_type"`
+	FileSize    int64     `json:"file_size"`
+	ExpiringUrl string    `json:"expiring_url"`
+	CreatedAt   time.Time `json:"created_at"`
+}
@@ -1,6 +1,12 @@
 package types
 
-import "time"
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
 
 type ProgramDetail struct {
 	Id            string            `json:"id"`
@@ -9,6 +15,8 @@ type ProgramDetail struct {
 	Relationships Relationships     `json:"relationships"`
 }
 
+// ProgramAttributes are the attributes of a program. Attributes returned by the API that aren't modeled here are kept
+// in Extra and written back out when marshaling, so no data is lost.
 type ProgramAttributes struct {
 	Handle                          string    `json:"handle,omitempty"`
 	Name                            string    `json:"name,omitempty"`
@@ -25,6 +33,70 @@ type ProgramAttributes struct {
 	Bookmarked                      bool      `json:"bookmarked,omitempty"`
 	AllowsBountySplitting           bool      `json:"allows_bounty_splitting,omitempty"`
 	OffersBounties                  bool      `json:"offers_bounties,omitempty"`
+	OpenScope                       bool      `json:"open_scope,omitempty"`
+	FastPayments                    bool      `json:"fast_payments,omitempty"`
+	GoldStandardSafeHarbor          bool      `json:"gold_standard_safe_harbor,omitempty"`
+	ResponseEfficiencyPercentage    int       `json:"response_efficiency_percentage,omitempty"`
+	Policy                          string    `json:"policy,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// programAttributeKeys are the json keys of the modeled attributes.
+var programAttributeKeys = sync.OnceValue(func() map[string]bool {
+	keys := map[string]bool{}
+	t := reflect.TypeFor[ProgramAttributes]()
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			keys[name] = true
+		}
+	}
+	return keys
+})
+
+func (a *ProgramAttributes) UnmarshalJSON(data []byte) error {
+	type attributes ProgramAttributes
+	if err := json.Unmarshal(data, (*attributes)(a)); err != nil {
+		return err
+	}
+
+	all := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &all); err != nil {
+		return err
+	}
+
+	a.Extra = nil
+	for key, value := range all {
+		if programAttributeKeys()[key] {
+			continue
+		} else if a.Extra == nil {
+			a.Extra = map[string]json.RawMessage{}
+		}
+		a.Extra[key] = value
+	}
+
+	return nil
+}
+
+func (a ProgramAttributes) MarshalJSON() ([]byte, error) {
+	type attributes ProgramAttributes
+	data, err := json.Marshal(attributes(a))
+	if err != nil || len(a.Extra) == 0 {
+		return data, err
+	}
+
+	all := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+	for key, value := range a.Extra {
+		if _, ok := all[key]; !ok {
+			all[key] = value
+		}
+	}
+
+	return json.Marshal(all)
 }
 
 type Relationships struct {